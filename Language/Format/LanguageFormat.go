@@ -18,6 +18,16 @@ func (f *FormatChecker) FormatTokens() {
 		switch f.Tokens[f.Index].Kind {
 		case tokenizer.EndOfStatment:
 			f.HandleNewLine()
+		case tokenizer.BooleanOp:
+			f.HandleReassignment()
+		case tokenizer.BlockEnd:
+			f.HandleClause()
+		case tokenizer.Fn:
+			f.HandleLambda()
+		case tokenizer.Record, tokenizer.Enum:
+			f.HandleMemberList()
+		case tokenizer.Match:
+			f.HandleMatch()
 		}
 		f.Index += 1
 	}
@@ -31,6 +41,204 @@ func (f *FormatChecker) HandleNewLine() {
 	f.FormatTokens()
 }
 
+// An `=` straight after the identifier which starts a statement gives the variable a new value
+// instead of comparing it, e.g. `count = count + 1`. The identifier can be a field, e.g. `person.age = 31`,
+// and there can be several with commas between them, e.g. `a, b = b, a`
+func (f *FormatChecker) HandleReassignment() {
+	if f.Tokens[f.Index].Text != "=" || f.Index == 0 || f.Tokens[f.Index-1].Kind != tokenizer.Identifier {
+		return
+	}
+	start := f.Index - 1
+	for {
+		for start >= 2 && f.Tokens[start-1].Kind == tokenizer.Dot && f.Tokens[start-2].Kind == tokenizer.Identifier {
+			start -= 2
+		}
+		if start < 2 || f.Tokens[start-1].Kind != tokenizer.Comma || f.Tokens[start-2].Kind != tokenizer.Identifier {
+			break
+		}
+		start -= 2
+	}
+	if start == 0 || f.Tokens[start-1].Kind == tokenizer.EndOfStatment || f.Tokens[start-1].Kind == tokenizer.BlockStart {
+		f.Tokens[f.Index].Kind = tokenizer.Reassign
+	}
+}
+
+// catch and finally start a new statement after the } of the block before them, so the block is ended the
+// same way as any other
+func (f *FormatChecker) HandleClause() {
+	if f.Index+1 >= len(f.Tokens) {
+		return
+	}
+	if next := f.Tokens[f.Index+1].Kind; next == tokenizer.Catch || next == tokenizer.Finally {
+		f.InsertNewLine(f.Index + 1)
+	}
+}
+
+// The fields of a record or the names in an enum can be written on one line with commas between them, e.g.
+// record Point { x, y }. The commas are turned into new lines so each one is a statement of the block, like the
+// lines of any other
+func (f *FormatChecker) HandleMemberList() {
+	i := f.Index
+	for i < len(f.Tokens) && f.Tokens[i].Kind != tokenizer.BlockStart && f.Tokens[i].Kind != tokenizer.EndOfStatment {
+		i += 1
+	}
+	depth, brackets := 0, 0
+	for ; i < len(f.Tokens); i++ {
+		switch f.Tokens[i].Kind {
+		case tokenizer.BlockStart:
+			depth += 1
+			if depth == 1 && f.Tokens[i+1].Kind != tokenizer.EndOfStatment {
+				f.InsertNewLine(i + 1)
+			}
+		case tokenizer.BlockEnd:
+			depth -= 1
+			if depth == 0 {
+				if f.Tokens[i-1].Kind != tokenizer.EndOfStatment {
+					f.InsertNewLine(i)
+				}
+				return
+			}
+		case tokenizer.Openbrack, tokenizer.OpenSquare:
+			brackets += 1
+		case tokenizer.Closebrack, tokenizer.CloseSquare:
+			brackets -= 1
+		case tokenizer.Comma:
+			if depth == 1 && brackets == 0 {
+				f.Tokens[i].Kind = tokenizer.EndOfStatment
+			}
+		case tokenizer.EndOfStatment:
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Each arm of a match, pattern => body, is turned into a case statement with the body in a block so it is read
+// in the same way as an if statement, case pattern { body }. The if of a guard, pattern if condition => body, is
+// kept in the head of the case as a guard operator. A line without => is left alone
+func (f *FormatChecker) HandleMatch() {
+	start := f.Index
+	for start < len(f.Tokens) && f.Tokens[start].Kind != tokenizer.BlockStart {
+		if f.Tokens[start].Kind == tokenizer.EndOfStatment {
+			return
+		}
+		start += 1
+	}
+	if start == len(f.Tokens) {
+		return
+	}
+
+	tokens := append([]tokenizer.Token{}, f.Tokens[:start+1]...)
+	tokens = append(tokens, newLineAfter(f.Tokens[start]))
+	for i := start + 1; i < len(f.Tokens); {
+		switch f.Tokens[i].Kind {
+		case tokenizer.EndOfStatment, tokenizer.Comma:
+			// Blank lines, and commas after the block of an arm
+			i += 1
+			continue
+		case tokenizer.BlockEnd:
+			tokens = append(tokens, f.Tokens[i])
+			f.Tokens = append(tokens, f.Tokens[i+1:]...)
+			return
+		}
+
+		arrow := f.findArmArrow(i)
+		if arrow < 0 {
+			end := i
+			for end < len(f.Tokens) && f.Tokens[end].Kind != tokenizer.EndOfStatment && f.Tokens[end].Kind != tokenizer.BlockEnd {
+				end += 1
+			}
+			tokens = append(tokens, f.Tokens[i:end]...)
+			tokens = append(tokens, newLineAfter(f.Tokens[end-1]))
+			i = end
+			continue
+		}
+
+		tokens = append(tokens, tokenizer.CreateToken("case", tokenizer.Case, f.Tokens[i].Cursor, f.Tokens[i].LineNum))
+		for _, token := range f.Tokens[i:arrow] {
+			if token.Kind == tokenizer.If {
+				token.Kind = tokenizer.Guard
+			}
+			tokens = append(tokens, token)
+		}
+		blockStart := tokenizer.CreateToken("{", tokenizer.BlockStart, f.Tokens[arrow].Cursor, f.Tokens[arrow].LineNum)
+		tokens = append(tokens, blockStart, newLineAfter(blockStart))
+
+		i = arrow + 1
+		if i < len(f.Tokens) && f.Tokens[i].Kind == tokenizer.BlockStart {
+			// The body is already in a block, it is copied up to the } which closes it
+			depth := 0
+			for i += 1; i < len(f.Tokens) && (f.Tokens[i].Kind != tokenizer.BlockEnd || depth > 0); i++ {
+				switch f.Tokens[i].Kind {
+				case tokenizer.BlockStart:
+					depth += 1
+				case tokenizer.BlockEnd:
+					depth -= 1
+				}
+				tokens = append(tokens, f.Tokens[i])
+			}
+			i += 1
+		} else {
+			for ; i < len(f.Tokens) && f.Tokens[i].Kind != tokenizer.EndOfStatment && f.Tokens[i].Kind != tokenizer.BlockEnd; i++ {
+				tokens = append(tokens, f.Tokens[i])
+			}
+			// A comma ending the line separates the arms, it isn't part of the body
+			if tokens[len(tokens)-1].Kind == tokenizer.Comma {
+				tokens = tokens[:len(tokens)-1]
+			}
+		}
+		last := tokens[len(tokens)-1]
+		if last.Kind != tokenizer.EndOfStatment {
+			tokens = append(tokens, newLineAfter(last))
+		}
+		blockEnd := tokenizer.CreateToken("}", tokenizer.BlockEnd, last.Cursor, last.LineNum)
+		tokens = append(tokens, blockEnd, newLineAfter(blockEnd))
+	}
+}
+
+// Finds the => of the arm starting at the index, outside of any brackets. Gives -1 when the line doesn't have one
+func (f *FormatChecker) findArmArrow(i int) int {
+	brackets := 0
+	for ; i < len(f.Tokens); i++ {
+		switch f.Tokens[i].Kind {
+		case tokenizer.Openbrack, tokenizer.OpenSquare:
+			brackets += 1
+		case tokenizer.Closebrack, tokenizer.CloseSquare:
+			brackets -= 1
+		case tokenizer.Arrow:
+			if brackets == 0 {
+				return i
+			}
+		case tokenizer.EndOfStatment, tokenizer.BlockStart, tokenizer.BlockEnd:
+			return -1
+		}
+	}
+	return -1
+}
+
+func newLineAfter(token tokenizer.Token) tokenizer.Token {
+	return tokenizer.CreateToken("NL", tokenizer.EndOfStatment, token.Cursor, token.LineNum)
+}
+
+// Puts a new line token at the index, before the token which is there now
+func (f *FormatChecker) InsertNewLine(index int) {
+	newLine := newLineAfter(f.Tokens[index-1])
+	newTokens := make([]tokenizer.Token, 0, len(f.Tokens)+1)
+	newTokens = append(newTokens, f.Tokens[:index]...)
+	newTokens = append(newTokens, newLine)
+	newTokens = append(newTokens, f.Tokens[index:]...)
+	f.Tokens = newTokens
+}
+
+// fn followed straight away by its parameters starts a lambda, e.g. fn(x) => x * 2, rather than declaring a
+// function with a name
+func (f *FormatChecker) HandleLambda() {
+	if f.Index+1 < len(f.Tokens) && f.Tokens[f.Index+1].Kind == tokenizer.Openbrack {
+		f.Tokens[f.Index].Kind = tokenizer.Lambda
+	}
+}
+
 func (f *FormatChecker) RemoveToken() {
 	newTokens := make([]tokenizer.Token, 0)
 	newTokens = append(newTokens, f.Tokens[:f.Index]...)
@@ -3,6 +3,7 @@ package LanErrs
 import (
 	"language/tokenizer"
 	"strconv"
+	"strings"
 )
 
 type MultipleErrors struct {
@@ -23,11 +24,28 @@ func (e IncompatibleTypeError) Error() string {
 }
 
 type NoIdentifierAvailableError struct {
+	Token      tokenizer.Token
 	Identifier string
+	Suggestion string
 }
 
 func (e NoIdentifierAvailableError) Error() string {
-	return "ERROR: Cannot find identifier \"" + e.Identifier + "\""
+	err := "ERROR: Cannot find identifier \"" + e.Identifier + "\" at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+	if e.Suggestion != "" {
+		err += "; did you mean \"" + e.Suggestion + "\"?"
+	}
+	return err
+}
+
+type AlreadyDeclaredError struct {
+	Token      tokenizer.Token
+	Identifier string
+}
+
+func (e AlreadyDeclaredError) Error() string {
+	return "ERROR: \"" + e.Identifier + "\" has already been declared in this scope, use = to give it a new value at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
 }
 
 type ExpectedBoolError struct {
@@ -53,7 +71,7 @@ type MustBeNumWithComparisonOp struct {
 }
 
 func (e MustBeNumWithComparisonOp) Error() string {
-	return "ERROR: Must use numbers on either side of Comparison operator at line num :" + strconv.Itoa(e.Token.LineNum) +
+	return "ERROR: Must use two numbers or two strings on either side of Comparison operator at line num :" + strconv.Itoa(e.Token.LineNum) +
 		", cursor :" + strconv.Itoa(e.Token.Cursor)
 }
 
@@ -74,3 +92,426 @@ func (e ExpectedIdentifierError) Error() string {
 	return "ERROR: Expected identifier next to Delete Operation at line num :" + strconv.Itoa(e.Token.LineNum) +
 		", cursor :" + strconv.Itoa(e.Token.Cursor)
 }
+
+type UnknownTypeError struct {
+	Token tokenizer.Token
+}
+
+func (e UnknownTypeError) Error() string {
+	return "ERROR: Unknown type \"" + e.Token.Text + "\" at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type TypeMismatchError struct {
+	Token      tokenizer.Token
+	Identifier string
+	Expected   string
+	Got        string
+}
+
+func (e TypeMismatchError) Error() string {
+	return "ERROR: Cannot assign " + e.Got + " to \"" + e.Identifier + "\" which has type " + e.Expected + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type DivisionByZeroError struct {
+	Token tokenizer.Token
+}
+
+func (e DivisionByZeroError) Error() string {
+	return "ERROR: Division by zero at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type IntegerOverflowError struct {
+	Token tokenizer.Token
+}
+
+func (e IntegerOverflowError) Error() string {
+	return "ERROR: Int is too big at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type PowerTooBigError struct {
+	Token tokenizer.Token
+}
+
+func (e PowerTooBigError) Error() string {
+	return "ERROR: The answer of the power is too big to work out at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type NotAFunctionError struct {
+	Token tokenizer.Token
+}
+
+func (e NotAFunctionError) Error() string {
+	return "ERROR: Only functions can be called at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type WrongNumberOfArgumentsError struct {
+	Token    tokenizer.Token
+	Function string
+	Expected string
+	Got      int
+}
+
+func (e WrongNumberOfArgumentsError) Error() string {
+	return "ERROR: \"" + e.Function + "\" takes " + e.Expected + " argument(s) but was given " + strconv.Itoa(e.Got) +
+		" at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type ConversionError struct {
+	Token tokenizer.Token
+	Value string
+	To    string
+}
+
+func (e ConversionError) Error() string {
+	return "ERROR: Cannot convert " + e.Value + " to " + e.To + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type WrongArgumentError struct {
+	Token    tokenizer.Token
+	Function string
+	Expected string
+	Got      string
+}
+
+func (e WrongArgumentError) Error() string {
+	return "ERROR: \"" + e.Function + "\" expected " + e.Expected + " but was given " + e.Got + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type FormatError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e FormatError) Error() string {
+	return "ERROR: Invalid format, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type UnexpectedCommaError struct {
+	Token tokenizer.Token
+}
+
+func (e UnexpectedCommaError) Error() string {
+	return "ERROR: Values joined by commas can't be used here at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type IndexOutOfRangeError struct {
+	Token  tokenizer.Token
+	Index  string
+	Length int
+}
+
+func (e IndexOutOfRangeError) Error() string {
+	return "ERROR: Index " + e.Index + " is out of range for length " + strconv.Itoa(e.Length) + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type NoMemberError struct {
+	Token  tokenizer.Token
+	Module string
+	Member string
+}
+
+func (e NoMemberError) Error() string {
+	return "ERROR: \"" + e.Module + "\" has nothing called \"" + e.Member + "\" at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type DomainError struct {
+	Token    tokenizer.Token
+	Function string
+	Value    string
+}
+
+func (e DomainError) Error() string {
+	return "ERROR: \"" + e.Function + "\" has no answer for " + e.Value + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type PermissionError struct {
+	Token tokenizer.Token
+	Path  string
+}
+
+func (e PermissionError) Error() string {
+	return "ERROR: Not allowed to use the file \"" + e.Path + "\" because it is outside the file root at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type FileError struct {
+	Token  tokenizer.Token
+	Path   string
+	Reason string
+}
+
+func (e FileError) Error() string {
+	return "ERROR: Cannot use the file \"" + e.Path + "\", " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type NoKeyError struct {
+	Token tokenizer.Token
+	Key   string
+}
+
+func (e NoKeyError) Error() string {
+	return "ERROR: The map has no key " + strconv.Quote(e.Key) + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type JSONError struct {
+	Token  tokenizer.Token
+	Offset int64
+	Reason string
+}
+
+func (e JSONError) Error() string {
+	return "ERROR: Invalid JSON at byte " + strconv.FormatInt(e.Offset, 10) + ", " + e.Reason + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type RepeatedArgumentError struct {
+	Token    tokenizer.Token
+	Function string
+	Argument string
+}
+
+func (e RepeatedArgumentError) Error() string {
+	return "ERROR: \"" + e.Function + "\" was given " + e.Argument + " more than once at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type CSVError struct {
+	Token  tokenizer.Token
+	Path   string
+	Line   int
+	Reason string
+}
+
+func (e CSVError) Error() string {
+	return "ERROR: Invalid CSV in \"" + e.Path + "\" on line " + strconv.Itoa(e.Line) + ", " + e.Reason + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type TimeRangeError struct {
+	Token tokenizer.Token
+}
+
+func (e TimeRangeError) Error() string {
+	return "ERROR: Time or duration is too big at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type PatternError struct {
+	Token   tokenizer.Token
+	Pattern string
+	Reason  string
+}
+
+func (e PatternError) Error() string {
+	return "ERROR: Invalid pattern " + strconv.Quote(e.Pattern) + ", " + e.Reason + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type ImportError struct {
+	Token  tokenizer.Token
+	Path   string
+	Reason string
+}
+
+func (e ImportError) Error() string {
+	return "ERROR: Cannot import \"" + e.Path + "\", " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type CyclicImportError struct {
+	Token tokenizer.Token
+	Chain []string
+}
+
+func (e CyclicImportError) Error() string {
+	return "ERROR: Cyclic import " + strings.Join(e.Chain, " -> ") + " at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+// Errors found in an imported module, each one is given with the path of the module
+type ModuleError struct {
+	Path string
+	Errs []error
+}
+
+func (e ModuleError) Error() string {
+	messages := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		messages[i] = err.Error() + " in \"" + e.Path + "\""
+	}
+	return strings.Join(messages, "\n")
+}
+
+// A value given to throw which isn't caught
+type ThrownError struct {
+	Token   tokenizer.Token
+	Message string
+}
+
+func (e ThrownError) Error() string {
+	return "ERROR: " + e.Message + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type FunctionSyntaxError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e FunctionSyntaxError) Error() string {
+	return "ERROR: Invalid function, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type ReturnOutsideFunctionError struct {
+	Token tokenizer.Token
+}
+
+func (e ReturnOutsideFunctionError) Error() string {
+	return "ERROR: return can only be used inside a function at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type RecursionError struct {
+	Token tokenizer.Token
+	Depth int
+}
+
+func (e RecursionError) Error() string {
+	return "ERROR: More than " + strconv.Itoa(e.Depth) + " function calls inside each other at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type RecordSyntaxError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e RecordSyntaxError) Error() string {
+	return "ERROR: Invalid record, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type DeleteFieldError struct {
+	Token tokenizer.Token
+	Field string
+}
+
+func (e DeleteFieldError) Error() string {
+	return "ERROR: \"" + e.Field + "\" is a field of a record and can't be deleted at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type EnumSyntaxError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e EnumSyntaxError) Error() string {
+	return "ERROR: Invalid enum, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type MatchSyntaxError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e MatchSyntaxError) Error() string {
+	return "ERROR: Invalid match, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+// Found by the checker, the program still runs
+type NonExhaustiveMatchWarning struct {
+	Token   tokenizer.Token
+	Enum    string
+	Missing []string
+}
+
+func (e NonExhaustiveMatchWarning) Error() string {
+	return "WARNING: match on \"" + e.Enum + "\" doesn't handle " + strings.Join(e.Missing, ", ") + " at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type AssignmentSyntaxError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e AssignmentSyntaxError) Error() string {
+	return "ERROR: Invalid assignment, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type ConstantError struct {
+	Token      tokenizer.Token
+	Identifier string
+}
+
+func (e ConstantError) Error() string {
+	return "ERROR: \"" + e.Identifier + "\" is a constant and can't be changed or deleted at line num :" +
+		strconv.Itoa(e.Token.LineNum) + ", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+type NotARealNumberError struct {
+	Token tokenizer.Token
+}
+
+func (e NotARealNumberError) Error() string {
+	return "ERROR: The answer is not a real number at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+type ExpectedMemberNameError struct {
+	Token tokenizer.Token
+}
+
+func (e ExpectedMemberNameError) Error() string {
+	return "ERROR: Expected a name after \".\" at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
+
+// A number literal which can't be read, Type is int or decimal
+type InvalidNumberError struct {
+	Token tokenizer.Token
+	Type  string
+}
+
+func (e InvalidNumberError) Error() string {
+	return "ERROR: Invalid " + e.Type + " \"" + e.Token.Text + "\" at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+// The value after - isn't a number, or the value after ! or not isn't a Bool
+type UnaryTypeError struct {
+	Token    tokenizer.Token
+	Expected string
+}
+
+func (e UnaryTypeError) Error() string {
+	return "ERROR: Expected " + e.Expected + " after \"" + e.Token.Text + "\" at line num :" + strconv.Itoa(e.Token.LineNum) +
+		", cursor :" + strconv.Itoa(e.Token.Cursor)
+}
+
+// Reading a line for input failed for a reason other than the input having ended
+type InputError struct {
+	Token  tokenizer.Token
+	Reason string
+}
+
+func (e InputError) Error() string {
+	return "ERROR: Cannot read input, " + e.Reason + " at line num :" + strconv.Itoa(e.Token.LineNum) + ", cursor :" +
+		strconv.Itoa(e.Token.Cursor)
+}
@@ -0,0 +1,162 @@
+package LanErrs
+
+import "language/tokenizer"
+
+// Every error the language gives has a code, the name of its type, which catch gives as err.code
+type Coded interface {
+	error
+	Code() string
+}
+
+// Errors which come from a place in the program give the token they were found at, catch gives its line and
+// column as err.line and err.column
+type Positioned interface {
+	error
+	Position() tokenizer.Token
+}
+
+func (e ModuleError) Code() string { return "ModuleError" }
+
+func (e IncompatibleTypeError) Code() string              { return "IncompatibleTypeError" }
+func (e IncompatibleTypeError) Position() tokenizer.Token { return e.Token }
+
+func (e NoIdentifierAvailableError) Code() string              { return "NoIdentifierAvailableError" }
+func (e NoIdentifierAvailableError) Position() tokenizer.Token { return e.Token }
+
+func (e AlreadyDeclaredError) Code() string              { return "AlreadyDeclaredError" }
+func (e AlreadyDeclaredError) Position() tokenizer.Token { return e.Token }
+
+func (e ExpectedBoolError) Code() string              { return "ExpectedBoolError" }
+func (e ExpectedBoolError) Position() tokenizer.Token { return e.Token }
+
+func (e ExpectedBoolWithControlError) Code() string              { return "ExpectedBoolWithControlError" }
+func (e ExpectedBoolWithControlError) Position() tokenizer.Token { return e.Token }
+
+func (e MustBeNumWithComparisonOp) Code() string              { return "MustBeNumWithComparisonOp" }
+func (e MustBeNumWithComparisonOp) Position() tokenizer.Token { return e.Token }
+
+func (e WrongTypeUsedWithBinOpError) Code() string              { return "WrongTypeUsedWithBinOpError" }
+func (e WrongTypeUsedWithBinOpError) Position() tokenizer.Token { return e.Token }
+
+func (e ExpectedIdentifierError) Code() string              { return "ExpectedIdentifierError" }
+func (e ExpectedIdentifierError) Position() tokenizer.Token { return e.Token }
+
+func (e UnknownTypeError) Code() string              { return "UnknownTypeError" }
+func (e UnknownTypeError) Position() tokenizer.Token { return e.Token }
+
+func (e TypeMismatchError) Code() string              { return "TypeMismatchError" }
+func (e TypeMismatchError) Position() tokenizer.Token { return e.Token }
+
+func (e DivisionByZeroError) Code() string              { return "DivisionByZeroError" }
+func (e DivisionByZeroError) Position() tokenizer.Token { return e.Token }
+
+func (e IntegerOverflowError) Code() string              { return "IntegerOverflowError" }
+func (e IntegerOverflowError) Position() tokenizer.Token { return e.Token }
+
+func (e PowerTooBigError) Code() string              { return "PowerTooBigError" }
+func (e PowerTooBigError) Position() tokenizer.Token { return e.Token }
+
+func (e NotAFunctionError) Code() string              { return "NotAFunctionError" }
+func (e NotAFunctionError) Position() tokenizer.Token { return e.Token }
+
+func (e WrongNumberOfArgumentsError) Code() string              { return "WrongNumberOfArgumentsError" }
+func (e WrongNumberOfArgumentsError) Position() tokenizer.Token { return e.Token }
+
+func (e ConversionError) Code() string              { return "ConversionError" }
+func (e ConversionError) Position() tokenizer.Token { return e.Token }
+
+func (e WrongArgumentError) Code() string              { return "WrongArgumentError" }
+func (e WrongArgumentError) Position() tokenizer.Token { return e.Token }
+
+func (e FormatError) Code() string              { return "FormatError" }
+func (e FormatError) Position() tokenizer.Token { return e.Token }
+
+func (e UnexpectedCommaError) Code() string              { return "UnexpectedCommaError" }
+func (e UnexpectedCommaError) Position() tokenizer.Token { return e.Token }
+
+func (e IndexOutOfRangeError) Code() string              { return "IndexOutOfRangeError" }
+func (e IndexOutOfRangeError) Position() tokenizer.Token { return e.Token }
+
+func (e NoMemberError) Code() string              { return "NoMemberError" }
+func (e NoMemberError) Position() tokenizer.Token { return e.Token }
+
+func (e DomainError) Code() string              { return "DomainError" }
+func (e DomainError) Position() tokenizer.Token { return e.Token }
+
+func (e PermissionError) Code() string              { return "PermissionError" }
+func (e PermissionError) Position() tokenizer.Token { return e.Token }
+
+func (e FileError) Code() string              { return "FileError" }
+func (e FileError) Position() tokenizer.Token { return e.Token }
+
+func (e NoKeyError) Code() string              { return "NoKeyError" }
+func (e NoKeyError) Position() tokenizer.Token { return e.Token }
+
+func (e JSONError) Code() string              { return "JSONError" }
+func (e JSONError) Position() tokenizer.Token { return e.Token }
+
+func (e RepeatedArgumentError) Code() string              { return "RepeatedArgumentError" }
+func (e RepeatedArgumentError) Position() tokenizer.Token { return e.Token }
+
+func (e CSVError) Code() string              { return "CSVError" }
+func (e CSVError) Position() tokenizer.Token { return e.Token }
+
+func (e TimeRangeError) Code() string              { return "TimeRangeError" }
+func (e TimeRangeError) Position() tokenizer.Token { return e.Token }
+
+func (e PatternError) Code() string              { return "PatternError" }
+func (e PatternError) Position() tokenizer.Token { return e.Token }
+
+func (e ImportError) Code() string              { return "ImportError" }
+func (e ImportError) Position() tokenizer.Token { return e.Token }
+
+func (e CyclicImportError) Code() string              { return "CyclicImportError" }
+func (e CyclicImportError) Position() tokenizer.Token { return e.Token }
+
+func (e ThrownError) Code() string              { return "ThrownError" }
+func (e ThrownError) Position() tokenizer.Token { return e.Token }
+
+func (e FunctionSyntaxError) Code() string              { return "FunctionSyntaxError" }
+func (e FunctionSyntaxError) Position() tokenizer.Token { return e.Token }
+
+func (e ReturnOutsideFunctionError) Code() string              { return "ReturnOutsideFunctionError" }
+func (e ReturnOutsideFunctionError) Position() tokenizer.Token { return e.Token }
+
+func (e RecursionError) Code() string              { return "RecursionError" }
+func (e RecursionError) Position() tokenizer.Token { return e.Token }
+
+func (e RecordSyntaxError) Code() string              { return "RecordSyntaxError" }
+func (e RecordSyntaxError) Position() tokenizer.Token { return e.Token }
+
+func (e DeleteFieldError) Code() string              { return "DeleteFieldError" }
+func (e DeleteFieldError) Position() tokenizer.Token { return e.Token }
+
+func (e EnumSyntaxError) Code() string              { return "EnumSyntaxError" }
+func (e EnumSyntaxError) Position() tokenizer.Token { return e.Token }
+
+func (e MatchSyntaxError) Code() string              { return "MatchSyntaxError" }
+func (e MatchSyntaxError) Position() tokenizer.Token { return e.Token }
+
+func (e NonExhaustiveMatchWarning) Code() string              { return "NonExhaustiveMatchWarning" }
+func (e NonExhaustiveMatchWarning) Position() tokenizer.Token { return e.Token }
+
+func (e AssignmentSyntaxError) Code() string              { return "AssignmentSyntaxError" }
+func (e AssignmentSyntaxError) Position() tokenizer.Token { return e.Token }
+
+func (e ConstantError) Code() string              { return "ConstantError" }
+func (e ConstantError) Position() tokenizer.Token { return e.Token }
+
+func (e NotARealNumberError) Code() string              { return "NotARealNumberError" }
+func (e NotARealNumberError) Position() tokenizer.Token { return e.Token }
+
+func (e ExpectedMemberNameError) Code() string              { return "ExpectedMemberNameError" }
+func (e ExpectedMemberNameError) Position() tokenizer.Token { return e.Token }
+
+func (e InvalidNumberError) Code() string              { return "InvalidNumberError" }
+func (e InvalidNumberError) Position() tokenizer.Token { return e.Token }
+
+func (e UnaryTypeError) Code() string              { return "UnaryTypeError" }
+func (e UnaryTypeError) Position() tokenizer.Token { return e.Token }
+
+func (e InputError) Code() string              { return "InputError" }
+func (e InputError) Position() tokenizer.Token { return e.Token }
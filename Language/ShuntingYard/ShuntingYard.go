@@ -2,34 +2,74 @@ package ShuntingYard
 
 import (
 	"language/tokenizer"
+	"strconv"
 )
 
 type ShuntingY struct {
-	Tokens []tokenizer.Token
-	stack  []tokenizer.Token
-	Result []tokenizer.Token
-	Index  int
+	Tokens   []tokenizer.Token
+	stack    []tokenizer.Token
+	Result   []tokenizer.Token
+	Index    int
+	brackets []bracket
 }
 
-var operations = map[tokenizer.TokenKind]struct {
+// Kept for each open bracket so the arguments of a function call or the items of a list can be counted.
+// kind is Call, Index or List, or End for brackets which only group
+type bracket struct {
+	kind  tokenizer.TokenKind
+	args  int
+	token tokenizer.Token
+	slice bool
+}
+
+type operator struct {
 	prec  int
 	assoc bool
-}{
-	tokenizer.Exspo:         {5, true},
-	tokenizer.Unary:         {5, true},
-	tokenizer.Multiply:      {4, false},
-	tokenizer.Divide:        {4, false},
-	tokenizer.Add:           {3, false},
-	tokenizer.Subtract:      {3, false},
-	tokenizer.BooleanOp:     {2, true},
-	tokenizer.BoolConnector: {2, false},
-	tokenizer.Input:         {2, false},
-	tokenizer.Assign:        {1, true},
-	tokenizer.Print:         {1, true},
-	tokenizer.Del:           {1, true},
-	tokenizer.EndOfStatment: {0, false},
-	tokenizer.BlockStart:    {0, false},
-	tokenizer.BlockEnd:      {0, false},
+}
+
+var operations = map[tokenizer.TokenKind]operator{
+	tokenizer.Dot:            {10, false},
+	tokenizer.Exspo:          {9, true},
+	tokenizer.Unary:          {9, true},
+	tokenizer.Multiply:       {8, false},
+	tokenizer.Divide:         {8, false},
+	tokenizer.IntDivide:      {8, false},
+	tokenizer.Modulo:         {8, false},
+	tokenizer.Add:            {7, false},
+	tokenizer.Subtract:       {7, false},
+	tokenizer.BooleanOp:      {6, true},
+	tokenizer.Range:          {6, true},
+	tokenizer.Input:          {6, false},
+	tokenizer.BoolConnector:  {4, false},
+	tokenizer.Comma:          {2, false},
+	tokenizer.Arrow:          {2, true},
+	tokenizer.Guard:          {2, false},
+	tokenizer.Assign:         {1, true},
+	tokenizer.Reassign:       {1, true},
+	tokenizer.CompoundAssign: {1, true},
+	tokenizer.Print:          {1, true},
+	tokenizer.Del:            {1, true},
+	tokenizer.Const:          {1, true},
+	tokenizer.Import:         {1, true},
+	tokenizer.Throw:          {1, true},
+	tokenizer.Return:         {1, true},
+	tokenizer.EndOfStatment:  {0, false},
+	tokenizer.BlockStart:     {0, false},
+	tokenizer.BlockEnd:       {0, false},
+}
+
+// & and | share a kind of token, as do ! and not, so they are told apart by their text. | and or bind looser than
+// & and and, and the keyword not applies to a whole comparison, e.g. not a = b is not (a = b), while ! only
+// applies to the value after it
+func operatorOf(token tokenizer.Token) (operator, bool) {
+	switch {
+	case token.Kind == tokenizer.BoolConnector && (token.Text == "|" || token.Text == "or"):
+		return operator{3, false}, true
+	case token.Kind == tokenizer.Unary && token.Text == "not":
+		return operator{5, true}, true
+	}
+	op, ok := operations[token.Kind]
+	return op, ok
 }
 
 func (s *ShuntingY) ToPostFix() {
@@ -50,6 +90,7 @@ func (s *ShuntingY) ToPostFix() {
 
 	switch true {
 	case isOpenBrack(s.Tokens[s.Index]):
+		s.handleOpenBrack()
 		s.stack = append(s.stack, s.Tokens[s.Index])
 		s.Index += 1
 		s.ToPostFix()
@@ -59,6 +100,27 @@ func (s *ShuntingY) ToPostFix() {
 		s.Index += 1
 		s.ToPostFix()
 
+	case isComma(s.Tokens[s.Index]):
+		s.handleComma()
+		s.Index += 1
+		s.ToPostFix()
+
+	case s.Tokens[s.Index].Kind == tokenizer.OpenSquare:
+		s.handleOpenSquare()
+		s.stack = append(s.stack, s.Tokens[s.Index])
+		s.Index += 1
+		s.ToPostFix()
+
+	case s.Tokens[s.Index].Kind == tokenizer.CloseSquare:
+		s.handleCloseSquare()
+		s.Index += 1
+		s.ToPostFix()
+
+	case s.Tokens[s.Index].Kind == tokenizer.Colon:
+		s.handleColon()
+		s.Index += 1
+		s.ToPostFix()
+
 	case isOp(s.Tokens[s.Index]):
 		s.testUnary()
 		s.handleOp()
@@ -97,8 +159,10 @@ func isCloseBrack(t tokenizer.Token) bool {
 func isOp(token tokenizer.Token) bool {
 	switch token.Kind {
 	case tokenizer.Exspo, tokenizer.Subtract, tokenizer.Add, tokenizer.Divide, tokenizer.Multiply,
-		tokenizer.Unary, tokenizer.BooleanOp, tokenizer.BoolConnector, tokenizer.Assign, tokenizer.Print,
-		tokenizer.BlockStart, tokenizer.BlockEnd, tokenizer.Input, tokenizer.Del:
+		tokenizer.IntDivide, tokenizer.Modulo, tokenizer.Unary, tokenizer.BooleanOp, tokenizer.BoolConnector, tokenizer.Assign, tokenizer.Reassign, tokenizer.Print,
+		tokenizer.BlockStart, tokenizer.BlockEnd, tokenizer.Input, tokenizer.Del, tokenizer.Import, tokenizer.Throw,
+		tokenizer.Dot, tokenizer.Arrow, tokenizer.Return, tokenizer.Guard, tokenizer.Range,
+		tokenizer.CompoundAssign, tokenizer.Const:
 		return true
 
 	default:
@@ -106,6 +170,100 @@ func isOp(token tokenizer.Token) bool {
 	}
 }
 
+func isComma(t tokenizer.Token) bool {
+	if t.Kind == tokenizer.Comma {
+		return true
+	}
+	return false
+}
+
+// Checks if the token before the current one finishes a value, so a bracket after it is a call or an index
+func (s *ShuntingY) followsValue() bool {
+	if s.Index == 0 {
+		return false
+	}
+	switch s.Tokens[s.Index-1].Kind {
+	case tokenizer.Identifier, tokenizer.String, tokenizer.Closebrack, tokenizer.CloseSquare, tokenizer.Lambda:
+		return true
+	}
+	return false
+}
+
+// A bracket straight after an identifier or another call is a function call, e.g. int("42"). The parameters
+// of a lambda are read the same way, e.g. fn(x)
+func (s *ShuntingY) handleOpenBrack() {
+	b := bracket{kind: tokenizer.End}
+	if s.followsValue() && s.Tokens[s.Index-1].Kind != tokenizer.String {
+		s.popMembers()
+		b.kind = tokenizer.Call
+		b.token = s.Tokens[s.Index-1]
+		if !isCloseBrack(s.Tokens[s.Index+1]) {
+			b.args = 1
+		}
+	}
+	s.brackets = append(s.brackets, b)
+}
+
+// A square bracket after a value indexes or slices it, e.g. name[0] or name[1:3], otherwise it starts a list
+func (s *ShuntingY) handleOpenSquare() {
+	b := bracket{kind: tokenizer.List, token: s.Tokens[s.Index]}
+	if s.followsValue() {
+		s.popMembers()
+		b.kind = tokenizer.Index
+	} else if s.Tokens[s.Index+1].Kind != tokenizer.CloseSquare {
+		b.args = 1
+	}
+	s.brackets = append(s.brackets, b)
+}
+
+// Finishes everything inside the square brackets then adds the index, slice or list token
+func (s *ShuntingY) handleCloseSquare() {
+	s.popUntil(tokenizer.OpenSquare)
+	s.stack = s.stack[:len(s.stack)-1]
+
+	b := s.brackets[len(s.brackets)-1]
+	s.brackets = s.brackets[:len(s.brackets)-1]
+	switch {
+	case b.kind == tokenizer.List:
+		s.Result = append(s.Result, tokenizer.CreateToken(strconv.Itoa(b.args), tokenizer.List, b.token.Cursor, b.token.LineNum))
+	case b.slice:
+		// A slice with no end, e.g. name[2:], is given nil as its end
+		if s.Tokens[s.Index-1].Kind == tokenizer.Colon {
+			s.Result = append(s.Result, tokenizer.CreateToken("nil", tokenizer.Nil, b.token.Cursor, b.token.LineNum))
+		}
+		s.Result = append(s.Result, tokenizer.CreateToken("[:]", tokenizer.Slice, b.token.Cursor, b.token.LineNum))
+	default:
+		s.Result = append(s.Result, tokenizer.CreateToken("[]", tokenizer.Index, b.token.Cursor, b.token.LineNum))
+	}
+}
+
+// Finishes the start of a slice. A slice with no start, e.g. name[:2], is given nil as its start
+func (s *ShuntingY) handleColon() {
+	s.popUntil(tokenizer.OpenSquare)
+	if s.Tokens[s.Index-1].Kind == tokenizer.OpenSquare {
+		s.Result = append(s.Result, tokenizer.CreateToken("nil", tokenizer.Nil, s.Tokens[s.Index].Cursor, s.Tokens[s.Index].LineNum))
+	}
+	if len(s.brackets) > 0 {
+		s.brackets[len(s.brackets)-1].slice = true
+	}
+}
+
+// Finishes any . before a call or index so math.sqrt(x) calls math.sqrt rather than sqrt
+func (s *ShuntingY) popMembers() {
+	for len(s.stack) > 0 && s.stack[len(s.stack)-1].Kind == tokenizer.Dot {
+		s.Result = append(s.Result, s.stack[len(s.stack)-1])
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+}
+
+// Moves operators from the stack to the result until the given kind of token is on top
+func (s *ShuntingY) popUntil(kind tokenizer.TokenKind) {
+	for len(s.stack) > 0 && s.stack[len(s.stack)-1].Kind != kind {
+		s.Result = append(s.Result, s.stack[len(s.stack)-1])
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+}
+
 func (s *ShuntingY) handleCloseBrack() {
 	for {
 		var op tokenizer.Token
@@ -117,14 +275,44 @@ func (s *ShuntingY) handleCloseBrack() {
 		}
 		s.Result = append(s.Result, op)
 	}
+
+	if len(s.brackets) == 0 {
+		return
+	}
+	b := s.brackets[len(s.brackets)-1]
+	s.brackets = s.brackets[:len(s.brackets)-1]
+	if b.kind == tokenizer.Call {
+		// The call token holds the number of arguments and the position of the function name
+		s.Result = append(s.Result, tokenizer.CreateToken(strconv.Itoa(b.args), tokenizer.Call, b.token.Cursor, b.token.LineNum))
+	}
+}
+
+// Finishes the argument before the comma. Outside of a function call or list the comma is an operator which
+// joins values together, e.g. print a, b
+func (s *ShuntingY) handleComma() {
+	if len(s.brackets) == 0 {
+		s.handleOp()
+		return
+	}
+	switch s.brackets[len(s.brackets)-1].kind {
+	case tokenizer.Call:
+		s.popUntil(tokenizer.Openbrack)
+	case tokenizer.List:
+		s.popUntil(tokenizer.OpenSquare)
+	default:
+		s.handleOp()
+		return
+	}
+	s.brackets[len(s.brackets)-1].args += 1
 }
 
 func (s *ShuntingY) handleOp() {
-	op1 := operations[s.Tokens[s.Index].Kind]
-	for len(s.stack) > 0 {
+	op1, _ := operatorOf(s.Tokens[s.Index])
+	// A prefix operator has no left side yet so nothing on the stack can be finished by it
+	for len(s.stack) > 0 && !isPrefixOp(s.Tokens[s.Index]) {
 		topOp := s.stack[len(s.stack)-1] // Token on top of the stack
 
-		if op2, isOperator := operations[topOp.Kind]; !isOperator || op1.prec > op2.prec ||
+		if op2, isOperator := operatorOf(topOp); !isOperator || op1.prec > op2.prec ||
 			op1.prec == op2.prec && op1.assoc {
 			break
 		}
@@ -136,11 +324,26 @@ func (s *ShuntingY) handleOp() {
 	s.stack = append(s.stack, s.Tokens[s.Index])
 }
 
+func isPrefixOp(token tokenizer.Token) bool {
+	switch token.Kind {
+	case tokenizer.Unary, tokenizer.Print, tokenizer.Input, tokenizer.Del, tokenizer.Import, tokenizer.Throw,
+		tokenizer.Return, tokenizer.Const:
+		return true
+
+	default:
+		return false
+	}
+}
+
 func (s *ShuntingY) testUnary() {
-	if s.Index == 0 || isOpenBrack(s.Tokens[s.Index-1]) || isOp(s.Tokens[s.Index-1]) ||
-		s.Tokens[s.Index-1].Kind == tokenizer.If || s.Tokens[s.Index-1].Kind == tokenizer.While {
+	if s.Index == 0 || isOpenBrack(s.Tokens[s.Index-1]) || isOp(s.Tokens[s.Index-1]) || isComma(s.Tokens[s.Index-1]) ||
+		s.Tokens[s.Index-1].Kind == tokenizer.OpenSquare || s.Tokens[s.Index-1].Kind == tokenizer.Colon ||
+		s.Tokens[s.Index-1].Kind == tokenizer.If || s.Tokens[s.Index-1].Kind == tokenizer.While ||
+		s.Tokens[s.Index-1].Kind == tokenizer.Match || s.Tokens[s.Index-1].Kind == tokenizer.Case {
 		if s.Tokens[s.Index].Kind != tokenizer.Print && s.Tokens[s.Index].Kind != tokenizer.Input &&
-			s.Tokens[s.Index].Kind != tokenizer.Del {
+			s.Tokens[s.Index].Kind != tokenizer.Del && s.Tokens[s.Index].Kind != tokenizer.Import &&
+			s.Tokens[s.Index].Kind != tokenizer.Throw && s.Tokens[s.Index].Kind != tokenizer.Return &&
+			s.Tokens[s.Index].Kind != tokenizer.Const {
 			s.Tokens[s.Index].Kind = tokenizer.Unary
 		}
 	}
@@ -0,0 +1,324 @@
+package decimal
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decides which way a number is rounded when digits have to be dropped
+type RoundingMode int
+
+const (
+	HalfEven RoundingMode = iota // to the nearest, halves go to the even digit (banker's rounding)
+	HalfUp                       // to the nearest, halves go away from zero
+	HalfDown                     // to the nearest, halves go towards zero
+	Down                         // towards zero
+	Up                           // away from zero
+	Floor                        // towards negative infinity
+	Ceiling                      // towards positive infinity
+)
+
+// Base 10 number which is stored exactly. The value is coefficient * 10^-scale
+type Decimal struct {
+	coefficient *big.Int
+	scale       int
+}
+
+var ten = big.NewInt(10)
+
+// Powers whose answers would have more digits than this aren't worked out, as they would take too long and use
+// too much memory
+const MaxPowerDigits = 1000000
+
+// The fewest digits base ^ n can have, worked out from the size of base without working out the power. n must
+// not be negative
+func PowerDigits(base *big.Int, n *big.Int) *big.Int {
+	bits := base.BitLen() - 1
+	if bits <= 0 {
+		return new(big.Int)
+	}
+	// A number with bits binary digits has at least 3 / 10 as many decimal digits
+	digits := new(big.Int).Mul(n, big.NewInt(int64(bits)*3))
+	return digits.Quo(digits, ten)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// Creates the decimal coefficient * 10^-scale
+func New(coefficient *big.Int, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{new(big.Int).Mul(coefficient, pow10(-scale)), 0}
+	}
+	return Decimal{new(big.Int).Set(coefficient), scale}
+}
+
+func FromInt(i *big.Int) Decimal {
+	return New(i, 0)
+}
+
+// Gives the shortest decimal which turns back into the same float
+func FromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, errors.New("cannot turn " + strconv.FormatFloat(f, 'g', -1, 64) + " into a decimal")
+	}
+	return Parse(strconv.FormatFloat(f, 'e', -1, 64))
+}
+
+// Reads a decimal such as "12", "-0.125" or "1.5e3". The exponent can be at most MaxPowerDigits either way, as a
+// bigger one would take too long to write out
+func Parse(s string) (Decimal, error) {
+	text := s
+	exponent := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Decimal{}, errors.New("invalid decimal \"" + s + "\"")
+		}
+		if err != nil || e > MaxPowerDigits || e < -MaxPowerDigits {
+			return Decimal{}, errors.New("the exponent of \"" + s + "\" is too big")
+		}
+		exponent = e
+		text = text[:i]
+	}
+
+	scale := 0
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		scale = len(text) - i - 1
+		text = text[:i] + text[i+1:]
+	}
+
+	digits := strings.TrimLeft(text, "+-")
+	if len(digits) == 0 || len(text)-len(digits) > 1 || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, errors.New("invalid decimal \"" + s + "\"")
+	}
+	coefficient, _ := new(big.Int).SetString(text, 10)
+	return New(coefficient, scale-exponent), nil
+}
+
+// The number written out in full with any trailing zeros after the decimal point removed
+func (d Decimal) String() string {
+	return d.trim().StringFixed(-1)
+}
+
+// The number written with exactly the given number of digits after the decimal point, rounding half to even.
+// A negative number of places writes all of the digits which are stored
+func (d Decimal) StringFixed(places int) string {
+	if places >= 0 {
+		d = d.Round(places, HalfEven)
+	}
+	digits := new(big.Int).Abs(d.coefficient).String()
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+
+	text := digits
+	if d.scale > 0 {
+		text = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if places > d.scale {
+		if d.scale == 0 {
+			text += "."
+		}
+		text += strings.Repeat("0", places-d.scale)
+	}
+	if d.coefficient.Sign() < 0 {
+		text = "-" + text
+	}
+	return text
+}
+
+// Removes trailing zeros after the decimal point
+func (d Decimal) trim() Decimal {
+	if d.coefficient == nil {
+		return Decimal{new(big.Int), 0}
+	}
+	coefficient := new(big.Int).Set(d.coefficient)
+	scale := d.scale
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, r := new(big.Int).QuoRem(coefficient, ten, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+		coefficient = quotient
+		scale--
+	}
+	return Decimal{coefficient, scale}
+}
+
+// Gives both decimals with the same scale so their coefficients can be used together
+func align(a Decimal, b Decimal) (*big.Int, *big.Int, int) {
+	switch {
+	case a.scale < b.scale:
+		return new(big.Int).Mul(a.coefficient, pow10(b.scale-a.scale)), b.coefficient, b.scale
+	case a.scale > b.scale:
+		return a.coefficient, new(big.Int).Mul(b.coefficient, pow10(a.scale-b.scale)), a.scale
+	}
+	return a.coefficient, b.coefficient, a.scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{new(big.Int).Add(a, b), scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{new(big.Int).Sub(a, b), scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.coefficient, o.coefficient), d.scale + o.scale}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Int).Neg(d.coefficient), d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{new(big.Int).Abs(d.coefficient), d.scale}
+}
+
+// Gives -1 when d is smaller than o, 0 when they are the same and 1 when d is bigger
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Sign() int {
+	return d.coefficient.Sign()
+}
+
+func (d Decimal) IsInteger() bool {
+	return d.trim().scale == 0
+}
+
+// The whole number part of the decimal, dropping anything after the decimal point
+func (d Decimal) BigInt() *big.Int {
+	return new(big.Int).Quo(d.coefficient, pow10(d.scale))
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.StringFixed(-1), 64)
+	return f
+}
+
+// Divides d by o giving an answer with at most precision significant digits. o must not be zero
+func (d Decimal) Quo(o Decimal, precision int, mode RoundingMode) Decimal {
+	// Shift d so the whole number quotient has more digits than are needed, then round it
+	shift := precision + 1 + numDigits(o.coefficient) - numDigits(d.coefficient)
+	if shift < 0 {
+		shift = 0
+	}
+	numerator := new(big.Int).Mul(d.coefficient, pow10(shift))
+	quotient, remainder := new(big.Int).QuoRem(numerator, o.coefficient, new(big.Int))
+	scale := d.scale - o.scale + shift
+
+	// A non zero digit is added to the end when the division wasn't exact so rounding can tell
+	// an exact half from a bit more than a half
+	if remainder.Sign() != 0 {
+		quotient.Mul(quotient, ten)
+		if numerator.Sign()*o.coefficient.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+		scale++
+	}
+	return New(quotient, scale).RoundSignificant(precision, mode).trim()
+}
+
+// The whole number part of d / o. o must not be zero
+func (d Decimal) QuoTrunc(o Decimal) Decimal {
+	a, b, _ := align(d, o)
+	return FromInt(new(big.Int).Quo(a, b))
+}
+
+// The remainder of d / o which has the same sign as d. o must not be zero
+func (d Decimal) Rem(o Decimal) Decimal {
+	return d.Sub(o.Mul(d.QuoTrunc(o)))
+}
+
+// Raises d to a whole number power. Negative powers are divided out to precision significant digits. Gives an
+// error when the answer would have more than MaxPowerDigits digits
+func (d Decimal) PowInt(n *big.Int, precision int, mode RoundingMode) (Decimal, error) {
+	if n.Sign() < 0 {
+		power, err := d.PowInt(new(big.Int).Neg(n), precision+2, mode)
+		if err != nil {
+			return Decimal{}, err
+		}
+		one := FromInt(big.NewInt(1))
+		return one.Quo(power, precision, mode), nil
+	}
+	// The digits after the decimal point are multiplied by n as well
+	scale := new(big.Int).Mul(n, big.NewInt(int64(d.scale)))
+	limit := big.NewInt(MaxPowerDigits)
+	if PowerDigits(d.coefficient, n).Cmp(limit) > 0 || scale.Cmp(limit) > 0 {
+		return Decimal{}, errors.New("the power is too big to work out")
+	}
+	return Decimal{new(big.Int).Exp(d.coefficient, n, nil), int(scale.Int64())}, nil
+}
+
+// Rounds to the given number of digits after the decimal point
+func (d Decimal) Round(places int, mode RoundingMode) Decimal {
+	if d.scale <= places {
+		return d
+	}
+	return New(roundDigits(d.coefficient, d.scale-places, mode), places)
+}
+
+// Rounds so there are at most precision significant digits
+func (d Decimal) RoundSignificant(precision int, mode RoundingMode) Decimal {
+	drop := numDigits(d.coefficient) - precision
+	if drop <= 0 {
+		return d
+	}
+	return New(roundDigits(d.coefficient, drop, mode), d.scale-drop)
+}
+
+// Drops the last digits of a coefficient, rounding what is left
+func roundDigits(coefficient *big.Int, drop int, mode RoundingMode) *big.Int {
+	divisor := pow10(drop)
+	quotient, remainder := new(big.Int).QuoRem(coefficient, divisor, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// Compares the dropped digits with a half
+	half := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor)
+	awayFromZero := false
+	switch mode {
+	case HalfEven:
+		awayFromZero = half > 0 || half == 0 && quotient.Bit(0) == 1
+	case HalfUp:
+		awayFromZero = half >= 0
+	case HalfDown:
+		awayFromZero = half > 0
+	case Up:
+		awayFromZero = true
+	case Floor:
+		awayFromZero = coefficient.Sign() < 0
+	case Ceiling:
+		awayFromZero = coefficient.Sign() > 0
+	}
+
+	if awayFromZero {
+		if coefficient.Sign() < 0 {
+			return quotient.Sub(quotient, big.NewInt(1))
+		}
+		return quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+func numDigits(i *big.Int) int {
+	if i.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(i).String())
+}
@@ -2,15 +2,149 @@ package interpreter
 
 import (
 	"fmt"
+	"io"
+	"language/decimal"
+	"language/loader"
 	tree "language/syntax_tree"
+	"time"
 )
 
-func Interpret(treee []tree.Node) {
+// Changes a setting used when running the program, passed to Interpret
+type Option func(*tree.Settings)
+
+// Ints which get too big give an error instead of becoming big ints
+func WithStrictIntegers() Option {
+	return func(s *tree.Settings) {
+		s.StrictIntegers = true
+	}
+}
+
+// Number of significant digits kept when a decimal division isn't exact, the default is 28. At least 1 digit is
+// always kept
+func WithDecimalPrecision(digits int) Option {
+	return func(s *tree.Settings) {
+		if digits < 1 {
+			digits = 1
+		}
+		s.DecimalPrecision = digits
+	}
+}
+
+// How decimals are rounded when digits are dropped, the default is decimal.HalfEven
+func WithRounding(mode decimal.RoundingMode) Option {
+	return func(s *tree.Settings) {
+		s.Rounding = mode
+	}
+}
+
+// Reads input from r instead of os.Stdin
+func WithInput(r io.Reader) Option {
+	return func(s *tree.Settings) {
+		s.Stdin = r
+	}
+}
+
+// Writes print output, input prompts and errors to w instead of os.Stdout
+func WithOutput(w io.Writer) Option {
+	return func(s *tree.Settings) {
+		s.Stdout = w
+	}
+}
+
+// Starts the random module from the given seed so it gives the same numbers every time the program is run
+func WithSeed(seed int64) Option {
+	return func(s *tree.Settings) {
+		s.FixedSeed = true
+		s.Seed = seed
+	}
+}
+
+// Lets the file builtins use files inside dir. Without this option programs can't use files
+func WithFileRoot(dir string) Option {
+	return func(s *tree.Settings) {
+		s.FileRoot = dir
+	}
+}
+
+// time.now and time.sleep use clock instead of the system clock, e.g. a FakeClock so tests give the same output
+func WithClock(clock tree.Clock) Option {
+	return func(s *tree.Settings) {
+		s.Clock = clock
+	}
+}
+
+// A clock which starts at a fixed time and only moves when the program sleeps, so sleeping doesn't wait
+type FakeClock struct {
+	now time.Time
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// Directories searched for imports which aren't found relative to the importing file
+func WithImportPath(dirs ...string) Option {
+	return func(s *tree.Settings) {
+		s.ImportPath = append(s.ImportPath, dirs...)
+	}
+}
+
+// Reads the program from a file and runs it. Imports in the program are found relative to the file
+func InterpretFile(path string, options ...Option) {
+	treee, errs := loader.ParseFile(path)
+	if len(errs) > 0 {
+		settings := newSettings(options)
+		for _, err := range errs {
+			fmt.Fprintln(settings.Stdout, err)
+		}
+		return
+	}
+	Interpret(treee, append([]Option{withScriptPath(path)}, options...)...)
+}
+
+func withScriptPath(path string) Option {
+	return func(s *tree.Settings) {
+		s.ScriptPath = path
+	}
+}
+
+func newSettings(options []Option) tree.Settings {
+	settings := tree.DefaultSettings()
+	settings.Parse = loader.ParseFile
+	for _, option := range options {
+		option(&settings)
+	}
+	return settings
+}
+
+func Interpret(treee []tree.Node, options ...Option) {
+	settings := newSettings(options)
+	tree.Configure(settings)
+
+	errs, warnings, folds := tree.Check(treee)
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintln(settings.Stdout, err)
+		}
+		return
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(settings.Stdout, warning)
+	}
+	tree.Fold(folds)
 
 	for _, node := range treee {
 		_, err := node.Evaluate()
 		if err != nil {
-			fmt.Println(err)
+			fmt.Fprintln(settings.Stdout, err)
 		}
 	}
 
@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"bufio"
+	"io"
+	"language/Format"
+	"language/Global"
+	"language/ShuntingYard"
+	"language/parser"
+	tree "language/syntax_tree"
+	"language/tokenizer"
+	"os"
+	"strconv"
+)
+
+// Reads a program from a file and parses it. The errors are the ones found while reading the file and
+// tokenizing it, when there are any no lines are given
+func ParseFile(path string) ([]tree.Node, []error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Turns the text of a program into the lines of its syntax tree
+func Parse(r io.Reader) ([]tree.Node, []error) {
+	var tokens []tokenizer.Token
+	var errs []error
+
+	scanner := bufio.NewScanner(r)
+	myTokenizer := tokenizer.New()
+
+	for scanner.Scan() {
+		myTokenizer.NewLine(scanner.Text())
+		token, err := myTokenizer.Get()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tokens = append(tokens, token)
+		for token.Kind != tokenizer.EndOfStatment {
+			token, err = myTokenizer.Get()
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	tokens = append(tokens, tokenizer.CreateToken("END", tokenizer.End, 0, 0))
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	FormatChecker := Format.NewFormatChecker(tokens)
+	FormatChecker.FormatTokens()
+	tokens = FormatChecker.Tokens
+
+	handleStrings(&tokens)
+	postFixTokens := ShuntingYard.ShuntingY{Tokens: tokens, Index: 0}
+	postFixTokens.ToPostFix()
+	post := postFixTokens.Result
+
+	parseTree := parser.NewParser(post)
+	parseTree.EvaluateToken()
+	return parseTree.ParsedLines, nil
+}
+
+// Strings are moved into Global.Strings and identifiers into Global.GlobalVarNames, the token text is
+// replaced with their index
+func handleStrings(tokens *[]tokenizer.Token) {
+	for index, token := range *tokens {
+		if token.Kind == tokenizer.String {
+			Global.Strings = append(Global.Strings, token.Text)
+			address := len(Global.Strings) - 1
+			(*tokens)[index].Text = strconv.Itoa(address)
+		} else if token.Kind == tokenizer.Identifier {
+			if !contains(Global.GlobalVarNames, token.Text) {
+				Global.GlobalVarNames = append(Global.GlobalVarNames, token.Text)
+				address := len(Global.GlobalVarNames) - 1
+				(*tokens)[index].Text = strconv.Itoa(address)
+				continue
+			}
+			address := findIndex(token.Text)
+			(*tokens)[index].Text = strconv.Itoa(address)
+		}
+	}
+}
+
+func contains(elems []string, v string) bool {
+	for _, s := range elems {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func findIndex(varName string) int {
+	for p, v := range Global.GlobalVarNames {
+		if v == varName {
+			return p
+		}
+	}
+	return -1
+}
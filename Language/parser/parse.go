@@ -3,6 +3,7 @@ package parser
 import (
 	tree "language/syntax_tree"
 	"language/tokenizer"
+	"strconv"
 )
 
 type TreeBuilder struct {
@@ -35,6 +36,32 @@ func (t *TreeBuilder) EvaluateToken() {
 	case isIdentifier(t.tokens[t.index]):
 		t.handleIdentifier()
 
+	case isTypeAnnotation(t.tokens[t.index]):
+		t.handleTypeAnnotation()
+
+	case isCall(t.tokens[t.index]):
+		t.handleCall()
+
+	case isComma(t.tokens[t.index]):
+		t.handleComma()
+
+	case isSquareOp(t.tokens[t.index]):
+		t.handleSquareOp()
+
+	case t.tokens[t.index].Kind == tokenizer.Dot:
+		t.handleDot()
+
+	case t.tokens[t.index].Kind == tokenizer.Lambda:
+		t.Stack = append(t.Stack, tree.FnNode{Token: t.tokens[t.index]})
+		t.index += 1
+		t.EvaluateToken()
+
+	case t.tokens[t.index].Kind == tokenizer.Arrow:
+		t.handleArrow()
+
+	case t.tokens[t.index].Kind == tokenizer.Guard, t.tokens[t.index].Kind == tokenizer.Range:
+		t.handlePatternOp()
+
 	case isAssingment(t.tokens[t.index]):
 		t.handleAssignment()
 
@@ -56,15 +83,20 @@ func (t *TreeBuilder) EvaluateToken() {
 }
 
 func isControl(token tokenizer.Token) bool {
-	if token.Kind == tokenizer.If || token.Kind == tokenizer.While {
+	if token.Kind == tokenizer.If || token.Kind == tokenizer.While || token.Kind == tokenizer.Try || token.Kind == tokenizer.Fn ||
+		token.Kind == tokenizer.Record || token.Kind == tokenizer.Enum || token.Kind == tokenizer.Match || token.Kind == tokenizer.Case {
 		return true
 	}
 	return false
 }
 
 func (t *TreeBuilder) handleFunc() {
-	right := popFromStack(&t.Stack)
 	token := t.tokens[t.index]
+	// return on its own gives back nil
+	if token.Kind == tokenizer.Return && len(t.Stack) == 0 {
+		t.Stack = append(t.Stack, tree.NilNode{Token: token})
+	}
+	right := popFromStack(&t.Stack)
 	switch token.Kind {
 	case tokenizer.Print:
 		t.Stack = append(t.Stack, tree.PrintNode{token, right})
@@ -74,6 +106,18 @@ func (t *TreeBuilder) handleFunc() {
 
 	case tokenizer.Del:
 		t.Stack = append(t.Stack, tree.DelNode{token, right})
+
+	case tokenizer.Import:
+		t.Stack = append(t.Stack, tree.ImportNode{Token: token, Right: right})
+
+	case tokenizer.Throw:
+		t.Stack = append(t.Stack, tree.ThrowNode{Token: token, Right: right})
+
+	case tokenizer.Return:
+		t.Stack = append(t.Stack, tree.ReturnNode{Token: token, Right: right})
+
+	case tokenizer.Const:
+		t.Stack = append(t.Stack, tree.ConstantNode{Token: token, Right: right})
 	}
 
 	t.index += 1
@@ -82,7 +126,7 @@ func (t *TreeBuilder) handleFunc() {
 
 func isFunc(token tokenizer.Token) bool {
 	switch token.Kind {
-	case tokenizer.Print, tokenizer.Input, tokenizer.Del:
+	case tokenizer.Print, tokenizer.Input, tokenizer.Del, tokenizer.Import, tokenizer.Throw, tokenizer.Return, tokenizer.Const:
 		return true
 	}
 	return false
@@ -100,13 +144,23 @@ func (t *TreeBuilder) handleAssignment() {
 	left := popFromStack(&t.Stack)
 	token := t.tokens[t.index]
 
-	t.Stack = append(t.Stack, tree.AssignmentNode{token, left, right})
+	_, multiple := left.(tree.TupleNode)
+	switch {
+	case token.Kind == tokenizer.CompoundAssign:
+		t.Stack = append(t.Stack, tree.CompoundAssignmentNode{Token: token, Left: left, Right: right})
+	case multiple:
+		t.Stack = append(t.Stack, tree.MultipleAssignmentNode{Token: token, Left: left, Right: right})
+	case token.Kind == tokenizer.Reassign:
+		t.Stack = append(t.Stack, tree.ReassignmentNode{Token: token, Left: left, Right: right})
+	default:
+		t.Stack = append(t.Stack, tree.AssignmentNode{token, left, right})
+	}
 	t.index += 1
 	t.EvaluateToken()
 }
 
 func isAssingment(token tokenizer.Token) bool {
-	if token.Kind == tokenizer.Assign {
+	if token.Kind == tokenizer.Assign || token.Kind == tokenizer.Reassign || token.Kind == tokenizer.CompoundAssign {
 		return true
 	}
 	return false
@@ -127,6 +181,138 @@ func isIdentifier(token tokenizer.Token) bool {
 	return false
 }
 
+func (t *TreeBuilder) handleTypeAnnotation() {
+	identifier := popFromStack(&t.Stack)
+	token := t.tokens[t.index]
+	t.Stack = append(t.Stack, tree.TypeAnnotationNode{Token: token, Identifier: identifier})
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+func isTypeAnnotation(token tokenizer.Token) bool {
+	if token.Kind == tokenizer.TypeAnnotation {
+		return true
+	}
+	return false
+}
+
+func (t *TreeBuilder) handleCall() {
+	token := t.tokens[t.index]
+	numOfArgs, _ := strconv.Atoi(token.Text)
+
+	args := make([]tree.Node, numOfArgs)
+	for i := numOfArgs - 1; i >= 0; i-- {
+		args[i] = popFromStack(&t.Stack)
+	}
+	callee := popFromStack(&t.Stack)
+	t.Stack = append(t.Stack, tree.CallNode{Token: token, Callee: callee, Args: args})
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+// The left side of => is the head of the lambda, e.g. fn(x), and the right side is its result
+func (t *TreeBuilder) handleArrow() {
+	body := popFromStack(&t.Stack)
+	head := popFromStack(&t.Stack)
+	t.Stack = append(t.Stack, tree.LambdaNode{Token: t.tokens[t.index], Head: head, Body: body})
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+// Ranges, e.g. 1..5, and guards, e.g. n if n > 0, are only used in the patterns of a match
+func (t *TreeBuilder) handlePatternOp() {
+	right := popFromStack(&t.Stack)
+	left := popFromStack(&t.Stack)
+	token := t.tokens[t.index]
+
+	if token.Kind == tokenizer.Range {
+		t.Stack = append(t.Stack, tree.RangeNode{Token: token, Left: left, Right: right})
+	} else {
+		t.Stack = append(t.Stack, tree.GuardNode{Token: token, Pattern: left, Condition: right})
+	}
+	t.index += 1
+	t.EvaluateToken()
+}
+
+func (t *TreeBuilder) handleDot() {
+	name := popFromStack(&t.Stack)
+	target := popFromStack(&t.Stack)
+	t.Stack = append(t.Stack, tree.MemberNode{Token: t.tokens[t.index], Target: target, Name: name})
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+// Builds indexes, slices and lists which were written with square brackets
+func (t *TreeBuilder) handleSquareOp() {
+	token := t.tokens[t.index]
+	switch token.Kind {
+	case tokenizer.Index:
+		index := popFromStack(&t.Stack)
+		target := popFromStack(&t.Stack)
+		t.Stack = append(t.Stack, tree.IndexNode{Token: token, Target: target, Index: index})
+
+	case tokenizer.Slice:
+		end := popFromStack(&t.Stack)
+		start := popFromStack(&t.Stack)
+		target := popFromStack(&t.Stack)
+		t.Stack = append(t.Stack, tree.SliceNode{Token: token, Target: target, Start: start, End: end})
+
+	case tokenizer.List:
+		numOfItems, _ := strconv.Atoi(token.Text)
+		items := make([]tree.Node, numOfItems)
+		for i := numOfItems - 1; i >= 0; i-- {
+			items[i] = popFromStack(&t.Stack)
+		}
+		t.Stack = append(t.Stack, tree.ListNode{Token: token, Items: items})
+	}
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+func isSquareOp(token tokenizer.Token) bool {
+	switch token.Kind {
+	case tokenizer.Index, tokenizer.Slice, tokenizer.List:
+		return true
+	}
+	return false
+}
+
+// Values joined by commas are collected into one TupleNode
+func (t *TreeBuilder) handleComma() {
+	right := popFromStack(&t.Stack)
+	left := popFromStack(&t.Stack)
+	token := t.tokens[t.index]
+
+	if tuple, ok := left.(tree.TupleNode); ok {
+		tuple.Items = append(tuple.Items, right)
+		t.Stack = append(t.Stack, tuple)
+	} else {
+		t.Stack = append(t.Stack, tree.TupleNode{Token: token, Items: []tree.Node{left, right}})
+	}
+
+	t.index += 1
+	t.EvaluateToken()
+}
+
+func isComma(token tokenizer.Token) bool {
+	if token.Kind == tokenizer.Comma {
+		return true
+	}
+	return false
+}
+
+func isCall(token tokenizer.Token) bool {
+	if token.Kind == tokenizer.Call {
+		return true
+	}
+	return false
+}
+
 func (t *TreeBuilder) handleBoolOp() {
 	right := popFromStack(&t.Stack)
 	left := popFromStack(&t.Stack)
@@ -151,10 +337,10 @@ func (t *TreeBuilder) handleBoolOp() {
 	case "=":
 		t.Stack = append(t.Stack, tree.DoesEqualNode{token, left, right})
 
-	case "&":
+	case "&", "and":
 		t.Stack = append(t.Stack, tree.AndNode{token, left, right})
 
-	case "|":
+	case "|", "or":
 		t.Stack = append(t.Stack, tree.OrNode{token, left, right})
 	}
 	t.index += 1
@@ -191,6 +377,16 @@ func (t *TreeBuilder) handleBinOp() {
 		left := popFromStack(&t.Stack)
 		t.Stack = append(t.Stack, tree.DivideNode{Token: token, Right: right, Left: left})
 
+	case tokenizer.IntDivide:
+		right := popFromStack(&t.Stack)
+		left := popFromStack(&t.Stack)
+		t.Stack = append(t.Stack, tree.IntDivideNode{Token: token, Right: right, Left: left})
+
+	case tokenizer.Modulo:
+		right := popFromStack(&t.Stack)
+		left := popFromStack(&t.Stack)
+		t.Stack = append(t.Stack, tree.ModuloNode{Token: token, Right: right, Left: left})
+
 	case tokenizer.Subtract:
 		right := popFromStack(&t.Stack)
 		left := popFromStack(&t.Stack)
@@ -234,13 +430,17 @@ func (t *TreeBuilder) handleLiteral() {
 	case tokenizer.Bool:
 		boolNode := tree.BoolNode{t.tokens[t.index]}
 		t.Stack = append(t.Stack, boolNode)
+
+	case tokenizer.Nil:
+		t.Stack = append(t.Stack, tree.NilNode{Token: t.tokens[t.index]})
 	}
 	t.index += 1
 	t.EvaluateToken()
 }
 
 func isLiteral(token tokenizer.Token) bool {
-	if token.Kind == tokenizer.Int || token.Kind == tokenizer.Decimal || token.Kind == tokenizer.String || token.Kind == tokenizer.Bool {
+	if token.Kind == tokenizer.Int || token.Kind == tokenizer.Decimal || token.Kind == tokenizer.String || token.Kind == tokenizer.Bool ||
+		token.Kind == tokenizer.Nil {
 		return true
 	}
 
@@ -249,7 +449,8 @@ func isLiteral(token tokenizer.Token) bool {
 
 func isBinOp(token tokenizer.Token) bool {
 	switch token.Kind {
-	case tokenizer.Exspo, tokenizer.Subtract, tokenizer.Add, tokenizer.Divide, tokenizer.Multiply, tokenizer.Unary:
+	case tokenizer.Exspo, tokenizer.Subtract, tokenizer.Add, tokenizer.Divide, tokenizer.Multiply, tokenizer.Unary,
+		tokenizer.IntDivide, tokenizer.Modulo:
 		return true
 
 	default:
@@ -13,6 +13,18 @@ func (t *TreeBuilder) handleControlStatement() {
 		t.handleIfStatement(controlType)
 	case tokenizer.While:
 		t.handleWhileStatement(controlType)
+	case tokenizer.Try:
+		t.handleTryStatement(controlType)
+	case tokenizer.Fn:
+		t.handleFunctionStatement(controlType)
+	case tokenizer.Record:
+		t.handleRecordStatement(controlType)
+	case tokenizer.Enum:
+		t.handleEnumStatement(controlType)
+	case tokenizer.Match:
+		t.handleMatchStatement(controlType)
+	case tokenizer.Case:
+		t.handleCaseStatement(controlType)
 	}
 	t.index += 1
 	t.EvaluateToken()
@@ -30,6 +42,71 @@ func (t *TreeBuilder) handleWhileStatement(whileToken tokenizer.Token) {
 	t.Stack = append(t.Stack, tree.WhileNode{whileToken, expression, statements})
 }
 
+// fn name(params) { } declares a function, the head is read like a call to it
+func (t *TreeBuilder) handleFunctionStatement(fnToken tokenizer.Token) {
+	head := t.getExpressionInFormSyntaxTree()
+	statements := t.getControlsStatementsInFormSyntaxTree()
+	t.Stack = append(t.Stack, tree.FunctionNode{Token: fnToken, Head: head, Statements: statements})
+}
+
+// record Name { } declares a record, each statement in the block is a field or a method
+func (t *TreeBuilder) handleRecordStatement(recordToken tokenizer.Token) {
+	name := t.getExpressionInFormSyntaxTree()
+	statements := t.getControlsStatementsInFormSyntaxTree()
+	t.Stack = append(t.Stack, tree.RecordNode{Token: recordToken, Name: name, Statements: statements})
+}
+
+// enum Name { } declares an enum, each statement in the block is one of its names
+func (t *TreeBuilder) handleEnumStatement(enumToken tokenizer.Token) {
+	name := t.getExpressionInFormSyntaxTree()
+	statements := t.getControlsStatementsInFormSyntaxTree()
+	t.Stack = append(t.Stack, tree.EnumNode{Token: enumToken, Name: name, Statements: statements})
+}
+
+// match value { } runs the first case in the block whose pattern the value matches
+func (t *TreeBuilder) handleMatchStatement(matchToken tokenizer.Token) {
+	expression := t.getExpressionInFormSyntaxTree()
+	statements := t.getControlsStatementsInFormSyntaxTree()
+	t.Stack = append(t.Stack, tree.MatchNode{Token: matchToken, Expression: expression, Cases: statements})
+}
+
+// The format checker turns each arm of a match, pattern => body, into case pattern { body }
+func (t *TreeBuilder) handleCaseStatement(caseToken tokenizer.Token) {
+	node := tree.CaseNode{Token: caseToken, Pattern: t.getExpressionInFormSyntaxTree()}
+	if guard, ok := node.Pattern.(tree.GuardNode); ok {
+		node.Pattern, node.Guard = guard.Pattern, guard.Condition
+	}
+	node.Statements = t.getControlsStatementsInFormSyntaxTree()
+	t.Stack = append(t.Stack, node)
+}
+
+// try { } can be followed by catch err { } and finally { }, each on the line the block before it ends
+func (t *TreeBuilder) handleTryStatement(tryToken tokenizer.Token) {
+	node := tree.TryNode{Token: tryToken, Statements: t.getControlsStatementsInFormSyntaxTree()}
+
+	if t.nextClause(tokenizer.Catch) {
+		node.Caught = true
+		if t.tokens[t.index].Kind == tokenizer.Identifier {
+			node.ErrName = tree.IdentifierNode{t.tokens[t.index]}
+			t.index += 1
+		}
+		node.Catch = t.getControlsStatementsInFormSyntaxTree()
+	}
+	if t.nextClause(tokenizer.Finally) {
+		node.Finally = t.getControlsStatementsInFormSyntaxTree()
+	}
+	t.Stack = append(t.Stack, node)
+}
+
+// Moves past the end of the block to a catch or finally of the given kind, the index is left alone if there isn't one
+func (t *TreeBuilder) nextClause(kind tokenizer.TokenKind) bool {
+	if t.index+2 >= len(t.tokens) || t.tokens[t.index+1].Kind != tokenizer.EndOfStatment || t.tokens[t.index+2].Kind != kind {
+		return false
+	}
+	t.index += 3
+	return true
+}
+
 func (t *TreeBuilder) getControlsStatementsInFormSyntaxTree() []tree.Node {
 	statementsStart := t.index + 2
 	t.index += 1
@@ -0,0 +1,124 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+)
+
+// Changes a variable, or a field of a record, with an operator, e.g. count += 1 is count = count + 1. The
+// operators are +=, -=, *=, /= and ^=
+type CompoundAssignmentNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node CompoundAssignmentNode) Evaluate() (Value, error) {
+	if err := node.checkTarget(); err != nil {
+		return Value{}, err
+	}
+	value, err := node.operation().Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{}, reassignVar(node.Left, value, node.Token)
+}
+
+// The operation which gives the new value, errors in it are given at the operator
+func (node CompoundAssignmentNode) operation() Node {
+	switch node.Token.Text {
+	case "-=":
+		return SubtractNode{Token: node.Token, Left: node.Left, Right: node.Right}
+	case "*=":
+		return MultiplyNode{Token: node.Token, Left: node.Left, Right: node.Right}
+	case "/=":
+		return DivideNode{Token: node.Token, Left: node.Left, Right: node.Right}
+	case "^=":
+		return ExpoNode{Token: node.Token, Left: node.Left, Right: node.Right}
+	}
+	return AddNode{Token: node.Token, Left: node.Left, Right: node.Right}
+}
+
+// Only a variable or a field can be changed, a type can't be given to it
+func (node CompoundAssignmentNode) checkTarget() error {
+	switch node.Left.(type) {
+	case IdentifierNode, MemberNode:
+		return nil
+	}
+	return LanErrs.AssignmentSyntaxError{Token: node.Token, Reason: node.Token.Text + " must come after a variable or a field"}
+}
+
+// Gives several variables values at once, e.g. a, b := 1, 2 declares both and a, b = b, a swaps them. Every
+// value on the right is worked out before any variable is given one
+type MultipleAssignmentNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node MultipleAssignmentNode) Evaluate() (Value, error) {
+	targets, expressions, err := node.pairs()
+	if err != nil {
+		return Value{}, err
+	}
+	values := make([]Value, len(expressions))
+	for i, expression := range expressions {
+		if values[i], err = expression.Evaluate(); err != nil {
+			return Value{}, err
+		}
+	}
+
+	if node.Token.Kind == tokenizer.Assign {
+		// Nothing is declared when one of the names already is
+		for _, target := range targets {
+			identifier, _ := assignedIdentifier(target, node.Token)
+			if _, ok := currentScope.vars[identifierName(identifier)]; ok {
+				return Value{}, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: identifierName(identifier)}
+			}
+		}
+	}
+
+	for i, target := range targets {
+		if node.Token.Kind == tokenizer.Assign {
+			err = declareVar(target, values[i], node.Token)
+		} else {
+			err = reassignVar(target, values[i], node.Token)
+		}
+		if err != nil {
+			return Value{}, err
+		}
+	}
+	return Value{}, nil
+}
+
+// Gives the targets on the left and the expressions on the right. There must be as many of each and a variable
+// can only be on the left once
+func (node MultipleAssignmentNode) pairs() ([]Node, []Node, error) {
+	targets := node.Left.(TupleNode).Items
+	expressions := []Node{node.Right}
+	if tuple, ok := node.Right.(TupleNode); ok {
+		expressions = tuple.Items
+	}
+	if len(targets) != len(expressions) {
+		return nil, nil, LanErrs.AssignmentSyntaxError{Token: node.Token,
+			Reason: strconv.Itoa(len(targets)) + " names are given " + strconv.Itoa(len(expressions)) + " values"}
+	}
+
+	names := map[string]bool{}
+	for _, target := range targets {
+		if _, ok := target.(MemberNode); ok && node.Token.Kind == tokenizer.Reassign {
+			continue
+		}
+		identifier, err := assignedIdentifier(target, node.Token)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := identifierName(identifier)
+		if names[name] {
+			return nil, nil, LanErrs.AssignmentSyntaxError{Token: identifier.Token, Reason: "\"" + name + "\" is given two values"}
+		}
+		names[name] = true
+	}
+	return targets, expressions, nil
+}
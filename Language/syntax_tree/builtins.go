@@ -0,0 +1,393 @@
+package tree
+
+import (
+	"fmt"
+	"language/Global"
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Functions are kept in this list and the Value holds their index, in the same way as big ints
+var functions []function
+
+// minArgs and maxArgs are the number of arguments the function can take, maxArgs is -1 when there is no limit.
+// named holds the names of the arguments after minArgs in order, these can also be given as name=value.
+// An argument which is skipped over by a named one is nil
+type function struct {
+	name    string
+	minArgs int
+	maxArgs int
+	named   []string
+	call    func(args []Value, token tokenizer.Token) (Value, error)
+}
+
+// Checks the number of arguments given to a function
+func (f function) checkArgs(got int, token tokenizer.Token) error {
+	if got >= f.minArgs && (f.maxArgs < 0 || got <= f.maxArgs) {
+		return nil
+	}
+	expected := strconv.Itoa(f.minArgs)
+	switch {
+	case f.maxArgs < 0:
+		expected = "at least " + expected
+	case f.maxArgs != f.minArgs:
+		expected += " to " + strconv.Itoa(f.maxArgs)
+	}
+	return LanErrs.WrongNumberOfArgumentsError{Token: token, Function: f.name, Expected: expected, Got: got}
+}
+
+func functionValue(f function) Value {
+	functions = append(functions, f)
+	return Value{ValueType: Function, Value: uint64(len(functions) - 1)}
+}
+
+// Builtin functions live in the scope above the global one, so a program can use their names for its own variables
+var builtinScope = newBuiltinScope()
+
+func newBuiltinScope() *scope {
+	s := newScope(nil)
+	for _, f := range []function{
+		{name: "int", minArgs: 1, maxArgs: 1, call: toInt},
+		{name: "decimal", minArgs: 1, maxArgs: 1, call: toDecimal},
+		{name: "float", minArgs: 1, maxArgs: 1, call: toFloat},
+		{name: "str", minArgs: 1, maxArgs: 1, call: toStr},
+		{name: "bool", minArgs: 1, maxArgs: 1, call: toBool},
+		{name: "type", minArgs: 1, maxArgs: 1, call: typeOf},
+		{name: "format", minArgs: 1, maxArgs: -1, call: format},
+		{name: "len", minArgs: 1, maxArgs: 1, call: length},
+		{name: "upper", minArgs: 1, maxArgs: 1, call: upper},
+		{name: "lower", minArgs: 1, maxArgs: 1, call: lower},
+		{name: "trim", minArgs: 1, maxArgs: 1, call: trim},
+		{name: "split", minArgs: 1, maxArgs: 2, call: split},
+		{name: "join", minArgs: 2, maxArgs: 2, call: join},
+		{name: "contains", minArgs: 2, maxArgs: 2, call: contains},
+		{name: "startsWith", minArgs: 2, maxArgs: 2, call: startsWith},
+		{name: "replace", minArgs: 3, maxArgs: 3, call: replace},
+		{name: "find", minArgs: 2, maxArgs: 2, call: find},
+		{name: "repeat", minArgs: 2, maxArgs: 2, call: repeat},
+		{name: "readFile", minArgs: 1, maxArgs: 1, call: readFile},
+		{name: "writeFile", minArgs: 2, maxArgs: 2, call: writeFile},
+		{name: "appendFile", minArgs: 2, maxArgs: 2, call: appendFile},
+		{name: "readLines", minArgs: 1, maxArgs: 1, call: readLines},
+		{name: "exists", minArgs: 1, maxArgs: 1, call: exists},
+		{name: "listDir", minArgs: 1, maxArgs: 1, call: listDir},
+		{name: "keys", minArgs: 1, maxArgs: 1, call: keys},
+		{name: "map", minArgs: 2, maxArgs: 2, call: mapItems},
+		{name: "filter", minArgs: 2, maxArgs: 2, call: filterItems},
+		{name: "reduce", minArgs: 2, maxArgs: 3, call: reduceItems},
+		{name: "range", minArgs: 1, maxArgs: 3, call: intRange},
+	} {
+		s.vars[f.name] = functionValue(f)
+	}
+	s.vars["math"] = newMathModule()
+	s.vars["random"] = newRandomModule()
+	s.vars["json"] = newJSONModule()
+	s.vars["csv"] = newCSVModule()
+	s.vars["time"] = newTimeModule()
+	s.vars["re"] = newRegexModule()
+	return s
+}
+
+// The kinds the conversion builtins give back, used by the checker
+var builtinKinds = map[string]valueKind{
+	"int":        Integer,
+	"decimal":    Decimal,
+	"float":      Float,
+	"str":        str,
+	"bool":       Bool,
+	"type":       str,
+	"format":     str,
+	"len":        Integer,
+	"upper":      str,
+	"lower":      str,
+	"trim":       str,
+	"split":      List,
+	"join":       str,
+	"contains":   Bool,
+	"startsWith": Bool,
+	"replace":    str,
+	"find":       Integer,
+	"repeat":     str,
+	"readFile":   str,
+	"readLines":  List,
+	"exists":     Bool,
+	"listDir":    List,
+	"keys":       List,
+	"map":        List,
+	"range":      List,
+}
+
+// Calling a function, e.g. int("42")
+type CallNode struct {
+	Token  tokenizer.Token
+	Callee Node
+	Args   []Node
+}
+
+func (node CallNode) Evaluate() (Value, error) {
+	callee, err := node.Callee.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	if callee.ValueType != Function {
+		return Value{}, LanErrs.NotAFunctionError{Token: node.Token}
+	}
+
+	f := functions[callee.Value]
+	if err := f.checkArgs(len(node.Args), node.Token); err != nil {
+		return Value{}, err
+	}
+
+	args := make([]Value, 0, len(node.Args))
+	given := map[int]bool{}
+	for _, arg := range node.Args {
+		slot, value := len(args), arg
+		if i, named, ok := f.namedArg(arg); ok {
+			slot, value = f.minArgs+i, named
+		}
+		if given[slot] {
+			return Value{}, LanErrs.RepeatedArgumentError{Token: node.Token, Function: f.name, Argument: f.argName(slot)}
+		}
+		given[slot] = true
+
+		for len(args) <= slot {
+			args = append(args, Value{ValueType: Nil})
+		}
+		args[slot], err = value.Evaluate()
+		if err != nil {
+			return Value{}, err
+		}
+	}
+	return f.call(args, node.Token)
+}
+
+// Finds an argument written as name=value where the function has an argument with that name
+func (f function) namedArg(arg Node) (int, Node, bool) {
+	equal, ok := arg.(DoesEqualNode)
+	if !ok {
+		return 0, nil, false
+	}
+	identifier, ok := equal.Left.(IdentifierNode)
+	if !ok {
+		return 0, nil, false
+	}
+	for i, name := range f.named {
+		if name == identifierName(identifier) {
+			return i, equal.Right, true
+		}
+	}
+	return 0, nil, false
+}
+
+// Gives the argument at index i, the second value is false when it wasn't given or is nil
+func optionalArg(args []Value, i int) (Value, bool) {
+	if i >= len(args) || args[i].ValueType == Nil {
+		return Value{}, false
+	}
+	return args[i], true
+}
+
+func (f function) argName(slot int) string {
+	if i := slot - f.minArgs; i >= 0 && i < len(f.named) {
+		return f.named[i]
+	}
+	return "argument " + strconv.Itoa(slot+1)
+}
+
+// The value used for something which is absent
+type NilNode struct {
+	Token tokenizer.Token
+}
+
+func (node NilNode) Evaluate() (Value, error) {
+	return Value{ValueType: Nil}, nil
+}
+
+func newString(s string) Value {
+	Global.Strings = append(Global.Strings, s)
+	return Value{ValueType: str, Value: uint64(len(Global.Strings) - 1)}
+}
+
+// The text of a value, the same as print shows it
+func valueString(v Value) string {
+	switch v.ValueType {
+	case Integer:
+		return strconv.Itoa(intUncast(v.Value))
+	case BigInteger:
+		return asBigInt(v).String()
+	case Decimal:
+		return asDecimal(v).String()
+	case Float:
+		return fmt.Sprint(FloatUncast(v.Value))
+	case Bool:
+		if v.Value == 1 {
+			return "true"
+		}
+		return "false"
+	case str:
+		return Global.Strings[v.Value]
+	case Function:
+		return "<function " + functions[v.Value].name + ">"
+	case Module:
+		return "<module " + modules[v.Value].name + ">"
+	case Map:
+		return maps[v.Value].String()
+	case Record:
+		return records[v.Value].String()
+	case Enum:
+		return enumMembers[v.Value].String()
+	case Time:
+		return times[v.Value].Format(time.RFC3339Nano)
+	case Duration:
+		return durationUncast(v.Value).String()
+	case Error:
+		message, _ := caughtErrors[v.Value].member("message")
+		return Global.Strings[message.Value]
+	case List:
+		items := make([]string, len(lists[v.Value]))
+		for i, item := range lists[v.Value] {
+			items[i] = describeValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return "nil"
+}
+
+// How a value is written in a conversion error or a list, strings are quoted so "" can be seen
+func describeValue(v Value) string {
+	if v.ValueType == str {
+		return strconv.Quote(Global.Strings[v.Value])
+	}
+	return valueString(v)
+}
+
+func conversionError(v Value, to string, token tokenizer.Token) error {
+	return LanErrs.ConversionError{Token: token, Value: describeValue(v), To: to}
+}
+
+// Decimals and floats are truncated towards zero, strings must hold a whole number
+func toInt(args []Value, token tokenizer.Token) (Value, error) {
+	v := args[0]
+	switch v.ValueType {
+	case Integer, BigInteger:
+		return v, nil
+
+	case Decimal:
+		return bigIntValue(asDecimal(v).BigInt(), token)
+
+	case Float:
+		f := FloatUncast(v.Value)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return Value{}, conversionError(v, "int", token)
+		}
+		i, _ := big.NewFloat(math.Trunc(f)).Int(nil)
+		return bigIntValue(i, token)
+
+	case str:
+		i, ok := new(big.Int).SetString(strings.TrimSpace(Global.Strings[v.Value]), 10)
+		if !ok {
+			return Value{}, conversionError(v, "int", token)
+		}
+		return bigIntValue(i, token)
+
+	case Bool:
+		return intValue(int(v.Value)), nil
+	}
+	return Value{}, conversionError(v, "int", token)
+}
+
+func toDecimal(args []Value, token tokenizer.Token) (Value, error) {
+	v := args[0]
+	switch v.ValueType {
+	case Integer, BigInteger, Decimal:
+		return decimalValue(asDecimal(v)), nil
+
+	case Float:
+		d, err := decimal.FromFloat(FloatUncast(v.Value))
+		if err != nil {
+			return Value{}, conversionError(v, "decimal", token)
+		}
+		return decimalValue(d), nil
+
+	case str:
+		d, err := decimal.Parse(strings.TrimSpace(Global.Strings[v.Value]))
+		if err != nil {
+			return Value{}, conversionError(v, "decimal", token)
+		}
+		return decimalValue(d), nil
+
+	case Bool:
+		return decimalValue(decimal.FromInt(big.NewInt(int64(v.Value)))), nil
+	}
+	return Value{}, conversionError(v, "decimal", token)
+}
+
+func toFloat(args []Value, token tokenizer.Token) (Value, error) {
+	v := args[0]
+	switch v.ValueType {
+	case Integer, BigInteger, Decimal, Float:
+		return FloatValue(asFloat(v)), nil
+
+	case str:
+		f, err := strconv.ParseFloat(strings.TrimSpace(Global.Strings[v.Value]), 64)
+		if err != nil {
+			return Value{}, conversionError(v, "float", token)
+		}
+		return FloatValue(f), nil
+
+	case Bool:
+		return FloatValue(float64(v.Value)), nil
+	}
+	return Value{}, conversionError(v, "float", token)
+}
+
+func toStr(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType == str {
+		return args[0], nil
+	}
+	return newString(valueString(args[0])), nil
+}
+
+// Numbers are false when they are zero, nil is false and strings must be "true" or "false"
+func toBool(args []Value, token tokenizer.Token) (Value, error) {
+	v := args[0]
+	switch v.ValueType {
+	case Bool:
+		return v, nil
+
+	case Integer, BigInteger, Decimal, Float:
+		if isZero(v) {
+			return Value{ValueType: Bool, Value: 0}, nil
+		}
+		return Value{ValueType: Bool, Value: 1}, nil
+
+	case str:
+		switch strings.TrimSpace(Global.Strings[v.Value]) {
+		case "true":
+			return Value{ValueType: Bool, Value: 1}, nil
+		case "false":
+			return Value{ValueType: Bool, Value: 0}, nil
+		}
+
+	case Nil:
+		return Value{ValueType: Bool, Value: 0}, nil
+	}
+	return Value{}, conversionError(v, "bool", token)
+}
+
+// A record, or an enum value, gives the name it was declared with
+func typeOf(args []Value, token tokenizer.Token) (Value, error) {
+	switch args[0].ValueType {
+	case Record:
+		return newString(records[args[0].Value].typ.name), nil
+	case Enum:
+		return newString(enumMembers[args[0].Value].enum), nil
+	}
+	return newString(kindName(args[0].ValueType)), nil
+}
@@ -0,0 +1,886 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+)
+
+// Walks the parsed lines before they are run so type errors are found without running the program
+type checker struct {
+	scopes   []map[string]checkedVar
+	errs     []error
+	warnings []error
+	// How many function bodies the checker is inside, return can only be used in one
+	functionDepth int
+	// Function bodies waiting to be checked once the rest of the block they are declared in has been
+	bodies []func()
+	// The values of the constants used at each name which can be folded, and how many times each name is declared
+	folds    Folds
+	declared map[string]int
+	// An import with a path which isn't a string literal could declare any name
+	unknownImport bool
+}
+
+// What the checker knows about a variable
+type checkedVar struct {
+	kind  valueKind
+	typed bool
+	// The names of an enum, in the order they were declared
+	members []string
+	// Declared with const. The value is known before the program runs when folded is true
+	constant bool
+	folded   bool
+	value    Value
+}
+
+// Gives the errors, which stop the program from running, the warnings, which don't, and the constants which can
+// be folded
+func Check(lines []Node) ([]error, []error, Folds) {
+	c := newChecker()
+	c.pushScope()
+	c.checkStatements(lines)
+	return c.errs, c.warnings, c.foldable()
+}
+
+func newChecker() checker {
+	return checker{folds: Folds{}, declared: map[string]int{}}
+}
+
+// A function can see variables declared after it, so a name which is declared anywhere else in the file could
+// refer to something other than the constant and isn't folded. Nothing is folded when an import could declare any
+// name
+func (c *checker) foldable() Folds {
+	if c.unknownImport {
+		return Folds{}
+	}
+	for token := range c.folds {
+		if c.declared[identifierName(IdentifierNode{Token: token})] > 1 {
+			delete(c.folds, token)
+		}
+	}
+	return c.folds
+}
+
+// Puts a variable in the innermost scope
+func (c *checker) declare(name string, variable checkedVar) {
+	c.innermost()[name] = variable
+	c.declared[name]++
+}
+
+func (c *checker) pushScope() {
+	c.scopes = append(c.scopes, make(map[string]checkedVar))
+}
+
+func (c *checker) popScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+func (c *checker) innermost() map[string]checkedVar {
+	return c.scopes[len(c.scopes)-1]
+}
+
+// Finds the nearest scope which has the variable, returns nil if there isn't one
+func (c *checker) find(name string) map[string]checkedVar {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i][name]; ok {
+			return c.scopes[i]
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkBlock(statements []Node) {
+	c.pushScope()
+	c.checkStatements(statements)
+	c.popScope()
+}
+
+// Function bodies are checked after the other statements of the block, as a function can use variables which
+// are declared after it but before it is called
+func (c *checker) checkStatements(statements []Node) {
+	start := len(c.bodies)
+	for _, statement := range statements {
+		c.checkStatement(statement)
+	}
+	bodies := append([]func(){}, c.bodies[start:]...)
+	c.bodies = c.bodies[:start]
+	for _, body := range bodies {
+		body()
+	}
+}
+
+func (c *checker) checkStatement(statement Node) {
+	switch node := statement.(type) {
+	case AssignmentNode:
+		c.checkAssignment(node)
+
+	case ReassignmentNode:
+		c.checkReassignment(node)
+
+	case CompoundAssignmentNode:
+		c.checkCompoundAssignment(node)
+
+	case MultipleAssignmentNode:
+		c.checkMultipleAssignment(node)
+
+	case DelNode:
+		//A del inside a nested body might not run, so only a del in the same scope removes the variable
+		if identifier, ok := node.Right.(IdentifierNode); ok {
+			name := identifierName(identifier)
+			if s := c.find(name); s != nil && s[name].constant {
+				c.errs = append(c.errs, LanErrs.ConstantError{Token: identifier.Token, Identifier: name})
+				return
+			}
+			delete(c.innermost(), name)
+		}
+
+	case ConstantNode:
+		c.checkConstant(node)
+
+	case ImportNode:
+		c.checkExpression(node.Right)
+		if path, ok := node.Right.(StringNode); ok {
+			value, _ := path.Evaluate()
+			c.declared[moduleName(Global.Strings[value.Value])]++
+		} else {
+			c.unknownImport = true
+		}
+
+	case IfNode:
+		c.checkExpression(node.Expression)
+		c.checkBlock(node.Statements)
+
+	case WhileNode:
+		c.checkExpression(node.Expression)
+		c.checkBlock(node.Statements)
+
+	case TryNode:
+		c.checkBlock(node.Statements)
+		if node.Caught {
+			c.pushScope()
+			if identifier, ok := node.ErrName.(IdentifierNode); ok {
+				c.declare(identifierName(identifier), checkedVar{})
+			}
+			c.checkStatements(node.Catch)
+			c.popScope()
+		}
+		c.checkBlock(node.Finally)
+
+	case FunctionNode:
+		c.checkFunction(node)
+
+	case RecordNode:
+		c.checkRecord(node)
+
+	case EnumNode:
+		c.checkEnum(node)
+
+	case MatchNode:
+		c.checkMatch(node)
+
+	default:
+		c.checkExpression(statement)
+	}
+}
+
+// Looks through an expression for operations which can't work with the kinds they are given
+func (c *checker) checkExpression(expression Node) {
+	switch node := expression.(type) {
+	case AndNode:
+		c.checkBoolSides(node.Token, node.Left, node.Right)
+
+	case OrNode:
+		c.checkBoolSides(node.Token, node.Left, node.Right)
+
+	case CallNode:
+		c.checkCall(node)
+		c.checkPattern(node)
+		c.checkExpression(node.Callee)
+		c.checkArgs(node.Args)
+		return
+
+	case PrintNode:
+		if tuple, ok := node.Right.(TupleNode); ok {
+			c.checkArgs(tuple.Items)
+			return
+		}
+
+	case IdentifierNode:
+		name := identifierName(node)
+		if s := c.find(name); s != nil && s[name].folded {
+			c.folds[node.Token] = s[name].value
+		}
+
+	case LambdaNode:
+		params, err := node.parameters()
+		if err != nil {
+			c.errs = append(c.errs, err)
+			return
+		}
+		c.checkFunctionBody(params, []Node{node.Body})
+		return
+
+	case FnNode:
+		_, err := node.Evaluate()
+		c.errs = append(c.errs, err)
+
+	case ReturnNode:
+		if c.functionDepth == 0 {
+			c.errs = append(c.errs, LanErrs.ReturnOutsideFunctionError{Token: node.Token})
+		}
+
+	case MemberNode:
+		if _, _, err := c.enumMember(node); err != nil {
+			c.errs = append(c.errs, err)
+		}
+	}
+
+	for _, child := range childNodes(expression) {
+		c.checkExpression(child)
+	}
+}
+
+// The name of an argument given by name isn't a variable, e.g. age in Person("Ann", age=30) or sep in
+// print a, b, sep=", "
+func (c *checker) checkArgs(args []Node) {
+	for _, arg := range args {
+		if equal, ok := arg.(DoesEqualNode); ok {
+			if _, ok := equal.Left.(IdentifierNode); ok {
+				c.checkExpression(equal.Right)
+				continue
+			}
+		}
+		c.checkExpression(arg)
+	}
+}
+
+// The name of a declared function is put in the scope before its body is checked, so it can call itself
+func (c *checker) checkFunction(node FunctionNode) {
+	identifier, params, err := node.signature()
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	name := identifierName(identifier)
+	if _, ok := c.innermost()[name]; ok {
+		c.errs = append(c.errs, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: name})
+		return
+	}
+	c.declare(name, checkedVar{})
+	c.checkFunctionBody(params, node.Statements)
+}
+
+// Parameters can hold any kind of value. The body is checked once the block it is declared in has been, with the
+// names which could be seen where it was declared and the names declared after it
+func (c *checker) checkFunctionBody(params []string, statements []Node) {
+	live := c.scopes
+	declared := make([]map[string]checkedVar, len(c.scopes))
+	for i, s := range c.scopes {
+		declared[i] = copyScope(s)
+	}
+	c.bodies = append(c.bodies, func() {
+		saved := c.scopes
+		c.scopes = make([]map[string]checkedVar, len(declared))
+		for i := range declared {
+			c.scopes[i] = mergeScopes(declared[i], live[i])
+		}
+		c.functionDepth++
+		c.pushScope()
+		for _, param := range params {
+			c.declare(param, checkedVar{})
+		}
+		c.checkStatements(statements)
+		c.popScope()
+		c.functionDepth--
+		c.scopes = saved
+	})
+}
+
+func copyScope(s map[string]checkedVar) map[string]checkedVar {
+	copied := make(map[string]checkedVar, len(s))
+	for name, variable := range s {
+		copied[name] = variable
+	}
+	return copied
+}
+
+// A name deleted after a function is still seen by its body, as the function could be called before the del. A
+// name which was declared again as something else could be either when the function is called, so anything can
+// be done with it
+func mergeScopes(declared map[string]checkedVar, later map[string]checkedVar) map[string]checkedVar {
+	merged := copyScope(declared)
+	for name, variable := range later {
+		if before, ok := merged[name]; ok && (before.kind != variable.kind || before.typed != variable.typed ||
+			before.constant != variable.constant) {
+			variable = checkedVar{}
+		}
+		merged[name] = variable
+	}
+	return merged
+}
+
+// Methods are checked with the fields of the record and self around them
+func (c *checker) checkRecord(node RecordNode) {
+	typ, identifier, err := node.recordType()
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	if _, ok := c.innermost()[typ.name]; ok {
+		c.errs = append(c.errs, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: typ.name})
+		return
+	}
+	c.declare(typ.name, checkedVar{})
+
+	for _, statement := range node.Statements {
+		method, ok := statement.(FunctionNode)
+		if !ok {
+			continue
+		}
+		c.pushScope()
+		for _, field := range typ.fields {
+			c.declare(field.name, checkedVar{kind: field.kind, typed: field.typed})
+		}
+		c.declare("self", checkedVar{})
+		_, params, _ := method.signature()
+		c.checkFunctionBody(params, method.Statements)
+		c.popScope()
+	}
+}
+
+func (c *checker) checkEnum(node EnumNode) {
+	identifier, names, err := node.members()
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	name := identifierName(identifier)
+	if _, ok := c.innermost()[name]; ok {
+		c.errs = append(c.errs, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: name})
+		return
+	}
+	c.declare(name, checkedVar{members: names})
+}
+
+// A match over the values of an enum gets a warning when some of them aren't handled. Cases with a guard might
+// not run, so they don't count
+func (c *checker) checkMatch(node MatchNode) {
+	c.checkExpression(node.Expression)
+
+	enum := ""
+	var members []string
+	handled := map[string]bool{}
+	wildcard := false
+	for _, statement := range node.Cases {
+		arm, ok := statement.(CaseNode)
+		if !ok {
+			c.errs = append(c.errs, LanErrs.MatchSyntaxError{Token: node.Token, Reason: "each line must be a pattern then => and what to run"})
+			return
+		}
+		for _, pattern := range alternatives(arm.Pattern) {
+			if identifier, ok := pattern.(IdentifierNode); ok && isWildcard(identifier) {
+				wildcard = wildcard || arm.Guard == nil
+				continue
+			}
+			c.checkExpression(pattern)
+			member, ok := pattern.(MemberNode)
+			if !ok {
+				continue
+			}
+			if name, names, _ := c.enumMember(member); names != nil {
+				enum, members = identifierName(member.Target.(IdentifierNode)), names
+				handled[name] = handled[name] || arm.Guard == nil
+			}
+		}
+		if arm.Guard != nil {
+			c.checkExpression(arm.Guard)
+		}
+		c.checkBlock(arm.Statements)
+	}
+
+	if members == nil || wildcard {
+		return
+	}
+	var missing []string
+	for _, member := range members {
+		if !handled[member] {
+			missing = append(missing, enum+"."+member)
+		}
+	}
+	if len(missing) > 0 {
+		c.warnings = append(c.warnings, LanErrs.NonExhaustiveMatchWarning{Token: node.Token, Enum: enum, Missing: missing})
+	}
+}
+
+// The patterns joined by | in a case
+func alternatives(pattern Node) []Node {
+	if or, ok := pattern.(OrNode); ok {
+		return append(alternatives(or.Left), alternatives(or.Right)...)
+	}
+	return []Node{pattern}
+}
+
+// Finds which value of an enum a node such as Status.Active is. The names of the enum are nil when the target
+// isn't an enum the checker knows about
+func (c *checker) enumMember(node MemberNode) (string, []string, error) {
+	target, ok := node.Target.(IdentifierNode)
+	name, isName := node.Name.(IdentifierNode)
+	if !ok || !isName {
+		return "", nil, nil
+	}
+	s := c.find(identifierName(target))
+	if s == nil || s[identifierName(target)].members == nil {
+		return "", nil, nil
+	}
+	members := s[identifierName(target)].members
+	for _, member := range members {
+		if member == identifierName(name) {
+			return member, members, nil
+		}
+	}
+	return "", nil, LanErrs.NoMemberError{Token: name.Token, Module: identifierName(target), Member: identifierName(name)}
+}
+
+// A constant made only from literals, and other constants which are, has its value worked out so it can be folded
+func (c *checker) checkConstant(node ConstantNode) {
+	assignment, identifier, err := node.assignment()
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	name := identifierName(identifier)
+	_, existed := c.innermost()[name]
+	c.checkAssignment(assignment)
+	if existed {
+		return
+	}
+
+	constant := c.innermost()[name]
+	constant.constant = true
+	if value, ok := c.literalValue(assignment.Right); ok {
+		if !constant.typed || value.ValueType == constant.kind {
+			constant.folded, constant.value = true, value
+			constant.kind, constant.typed = value.ValueType, true
+		}
+	}
+	c.innermost()[name] = constant
+}
+
+// Works out the value of an expression which only has literals, operators and constants which can be folded.
+// Gives false for any other expression, or when working it out gives an error
+func (c *checker) literalValue(expression Node) (Value, bool) {
+	switch node := expression.(type) {
+	case IntNode, DecimalNode, StringNode, BoolNode, NilNode:
+		value, err := node.Evaluate()
+		return value, err == nil
+	case IdentifierNode:
+		name := identifierName(node)
+		if s := c.find(name); s != nil && s[name].folded {
+			return s[name].value, true
+		}
+	case UnaryNode:
+		right, ok := c.literalValue(node.Right)
+		if ok {
+			value, err := UnaryNode{Token: node.Token, Right: foldedNode{Token: node.Token, value: right}}.Evaluate()
+			return value, err == nil
+		}
+	case AddNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return AddNode{Token: node.Token, Left: left, Right: right}
+		})
+	case SubtractNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return SubtractNode{Token: node.Token, Left: left, Right: right}
+		})
+	case MultiplyNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return MultiplyNode{Token: node.Token, Left: left, Right: right}
+		})
+	case DivideNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return DivideNode{Token: node.Token, Left: left, Right: right}
+		})
+	case IntDivideNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return IntDivideNode{Token: node.Token, Left: left, Right: right}
+		})
+	case ModuloNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return ModuloNode{Token: node.Token, Left: left, Right: right}
+		})
+	case ExpoNode:
+		return c.literalOperation(node.Token, node.Left, node.Right, func(left, right Node) Node {
+			return ExpoNode{Token: node.Token, Left: left, Right: right}
+		})
+	}
+	return Value{}, false
+}
+
+// Works out an operation on two sides whose values are known, the operation is made with the values in place of
+// the sides
+func (c *checker) literalOperation(token tokenizer.Token, left Node, right Node, operation func(Node, Node) Node) (Value, bool) {
+	leftValue, ok := c.literalValue(left)
+	if !ok {
+		return Value{}, false
+	}
+	rightValue, ok := c.literalValue(right)
+	if !ok {
+		return Value{}, false
+	}
+	value, err := operation(foldedNode{Token: token, value: leftValue}, foldedNode{Token: token, value: rightValue}).Evaluate()
+	return value, err == nil
+}
+
+// Both sides of & and | must be Bool even though the right side isn't always run
+func (c *checker) checkBoolSides(token tokenizer.Token, left Node, right Node) {
+	for _, side := range []Node{left, right} {
+		if kind, known := c.kindOf(side); known && kind != Bool {
+			c.errs = append(c.errs, LanErrs.ExpectedBoolError{Token: token})
+			return
+		}
+	}
+}
+
+// Calls to builtins which haven't been hidden by a variable must have the right number of arguments
+func (c *checker) checkCall(node CallNode) {
+	name, ok := c.builtinName(node.Callee)
+	if !ok {
+		return
+	}
+	if err := functions[builtinScope.vars[name].Value].checkArgs(len(node.Args), node.Token); err != nil {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// Patterns written as strings in calls to the re module are compiled before the program runs, so a bad pattern
+// is reported where it is written
+func (c *checker) checkPattern(node CallNode) {
+	member, ok := node.Callee.(MemberNode)
+	if !ok || len(node.Args) == 0 {
+		return
+	}
+	target, ok := member.Target.(IdentifierNode)
+	if !ok || identifierName(target) != "re" || c.find("re") != nil {
+		return
+	}
+	literal, ok := node.Args[0].(StringNode)
+	if !ok {
+		return
+	}
+	pattern, err := literal.Evaluate()
+	if err != nil {
+		return
+	}
+	if _, err := compilePattern(Global.Strings[pattern.Value], literal.Token); err != nil {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// Gives the name of the builtin a node refers to, the second value is false when it isn't a builtin
+func (c *checker) builtinName(node Node) (string, bool) {
+	identifier, ok := node.(IdentifierNode)
+	if !ok {
+		return "", false
+	}
+	name := identifierName(identifier)
+	if _, ok := builtinKinds[name]; !ok || c.find(name) != nil {
+		return "", false
+	}
+	return name, true
+}
+
+// The expressions directly below a node
+func childNodes(expression Node) []Node {
+	switch node := expression.(type) {
+	case AddNode:
+		return []Node{node.Left, node.Right}
+	case SubtractNode:
+		return []Node{node.Left, node.Right}
+	case MultiplyNode:
+		return []Node{node.Left, node.Right}
+	case DivideNode:
+		return []Node{node.Left, node.Right}
+	case IntDivideNode:
+		return []Node{node.Left, node.Right}
+	case ModuloNode:
+		return []Node{node.Left, node.Right}
+	case ExpoNode:
+		return []Node{node.Left, node.Right}
+	case OrNode:
+		return []Node{node.Left, node.Right}
+	case AndNode:
+		return []Node{node.Left, node.Right}
+	case DoesEqualNode:
+		return []Node{node.Left, node.Right}
+	case NotEqualNode:
+		return []Node{node.Left, node.Right}
+	case BigThanNode:
+		return []Node{node.Left, node.Right}
+	case BigThanEqualNode:
+		return []Node{node.Left, node.Right}
+	case SmallThanNode:
+		return []Node{node.Left, node.Right}
+	case SmallThanEqualNode:
+		return []Node{node.Left, node.Right}
+	case UnaryNode:
+		return []Node{node.Right}
+	case PrintNode:
+		return []Node{node.Right}
+	case InputNode:
+		return []Node{node.Right}
+	case CallNode:
+		return append([]Node{node.Callee}, node.Args...)
+	case TupleNode:
+		return node.Items
+	case ListNode:
+		return node.Items
+	case IndexNode:
+		return []Node{node.Target, node.Index}
+	case SliceNode:
+		return []Node{node.Target, node.Start, node.End}
+	case MemberNode:
+		return []Node{node.Target}
+	case ThrowNode:
+		return []Node{node.Right}
+	case ReturnNode:
+		return []Node{node.Right}
+	case RangeNode:
+		return []Node{node.Left, node.Right}
+	case GuardNode:
+		return []Node{node.Pattern, node.Condition}
+	}
+	return nil
+}
+
+func (c *checker) checkAssignment(node AssignmentNode) {
+	identifier, err := assignedIdentifier(node.Left, node.Token)
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	name := identifierName(identifier)
+
+	scope := c.innermost()
+	if existing, ok := scope[name]; ok {
+		if existing.constant {
+			c.errs = append(c.errs, LanErrs.ConstantError{Token: identifier.Token, Identifier: name})
+			return
+		}
+		c.errs = append(c.errs, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: name})
+		return
+	}
+
+	switch left := node.Left.(type) {
+	case IdentifierNode:
+		c.declare(name, checkedVar{})
+
+	case TypeAnnotationNode:
+		kind, ok := typeNames[left.Token.Text]
+		if !ok {
+			c.errs = append(c.errs, LanErrs.UnknownTypeError{Token: left.Token})
+			c.declare(name, checkedVar{})
+			return
+		}
+		c.declare(name, checkedVar{kind: kind, typed: true})
+	}
+
+	c.checkExpression(node.Right)
+	c.checkValueType(scope[name], name, node.Right, node.Token)
+}
+
+func (c *checker) checkReassignment(node ReassignmentNode) {
+	// The fields of a record are only known when the program runs
+	if member, ok := node.Left.(MemberNode); ok {
+		c.checkExpression(member)
+		c.checkExpression(node.Right)
+		return
+	}
+	identifier, err := assignedIdentifier(node.Left, node.Token)
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	name := identifierName(identifier)
+	c.checkExpression(node.Right)
+
+	s := c.find(name)
+	if s == nil {
+		c.errs = append(c.errs, noIdentifierError(identifier.Token, name))
+		return
+	}
+	if s[name].constant {
+		c.errs = append(c.errs, LanErrs.ConstantError{Token: identifier.Token, Identifier: name})
+		return
+	}
+	c.checkValueType(s[name], name, node.Right, node.Token)
+}
+
+// The new value is checked as the operation it comes from, e.g. count += 1 as count + 1
+func (c *checker) checkCompoundAssignment(node CompoundAssignmentNode) {
+	if err := node.checkTarget(); err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	c.checkExpression(node.Right)
+	identifier, ok := node.Left.(IdentifierNode)
+	if !ok {
+		c.checkExpression(node.Left)
+		return
+	}
+	name := identifierName(identifier)
+	s := c.find(name)
+	if s == nil {
+		c.errs = append(c.errs, noIdentifierError(identifier.Token, name))
+		return
+	}
+	if s[name].constant {
+		c.errs = append(c.errs, LanErrs.ConstantError{Token: identifier.Token, Identifier: name})
+		return
+	}
+	c.checkValueType(s[name], name, node.operation(), node.Token)
+}
+
+// Each variable is checked as if it was given its value on its own line
+func (c *checker) checkMultipleAssignment(node MultipleAssignmentNode) {
+	targets, expressions, err := node.pairs()
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return
+	}
+	for i, target := range targets {
+		if node.Token.Kind == tokenizer.Assign {
+			c.checkAssignment(AssignmentNode{Token: node.Token, Left: target, Right: expressions[i]})
+		} else {
+			c.checkReassignment(ReassignmentNode{Token: node.Token, Left: target, Right: expressions[i]})
+		}
+	}
+}
+
+// Reports an error when an expression can't be given to the variable because of its type
+func (c *checker) checkValueType(variable checkedVar, name string, expression Node, token tokenizer.Token) {
+	if !variable.typed {
+		return
+	}
+	got, known := c.kindOf(expression)
+	if !known || got == variable.kind || got == Nil || canWiden(got, variable.kind) {
+		return
+	}
+	c.errs = append(c.errs, LanErrs.TypeMismatchError{Token: token, Identifier: name, Expected: kindName(variable.kind), Got: kindName(got)})
+}
+
+// Works out the kind an expression will return. The second value is false when it can only be known at runtime
+func (c *checker) kindOf(expression Node) (valueKind, bool) {
+	switch node := expression.(type) {
+	case IntNode:
+		return Integer, true
+
+	case DecimalNode:
+		return Decimal, true
+
+	case StringNode:
+		return str, true
+
+	case InputNode:
+		switch node.Token.Text {
+		case "inputInt":
+			return Integer, true
+		case "inputDecimal":
+			return Decimal, true
+		}
+		return str, true
+
+	case NilNode:
+		return Nil, true
+
+	case ListNode:
+		return List, true
+
+	case LambdaNode:
+		return Function, true
+
+	case CallNode:
+		if name, ok := c.builtinName(node.Callee); ok {
+			return builtinKinds[name], true
+		}
+		return 0, false
+
+	case BoolNode, OrNode, AndNode, DoesEqualNode, NotEqualNode, BigThanNode, BigThanEqualNode, SmallThanNode,
+		SmallThanEqualNode:
+		return Bool, true
+
+	case IdentifierNode:
+		name := identifierName(node)
+		if s := c.find(name); s != nil && s[name].typed {
+			return s[name].kind, true
+		}
+		return 0, false
+
+	case UnaryNode:
+		if node.Token.Text == "-" {
+			return c.kindOf(node.Right)
+		}
+		return Bool, true
+
+	case AddNode:
+		return c.binOpKind(node.Left, node.Right, true)
+
+	case SubtractNode:
+		return c.binOpKind(node.Left, node.Right, false)
+
+	case MultiplyNode:
+		return c.binOpKind(node.Left, node.Right, false)
+
+	case DivideNode:
+		if kind, ok := c.binOpKind(node.Left, node.Right, false); ok && isNum(kind) {
+			if kind == Float {
+				return Float, true
+			}
+			return Decimal, true
+		}
+		return 0, false
+
+	case IntDivideNode:
+		return c.binOpKind(node.Left, node.Right, false)
+
+	case ModuloNode:
+		return c.binOpKind(node.Left, node.Right, false)
+
+	case ExpoNode:
+		return c.binOpKind(node.Left, node.Right, false)
+	}
+	return 0, false
+}
+
+func (c *checker) binOpKind(left Node, right Node, allowStrings bool) (valueKind, bool) {
+	l, ok := c.kindOf(left)
+	if !ok {
+		return 0, false
+	}
+	r, ok := c.kindOf(right)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case l == Integer && r == Integer:
+		return Integer, true
+	case l == Float && isNum(r) || r == Float && isNum(l):
+		return Float, true
+	case isNum(l) && isNum(r):
+		return Decimal, true
+	case allowStrings && l == str && r == str:
+		return str, true
+	}
+	return 0, false
+}
+
+// The parser stores an index into Global.GlobalVarNames as the token text
+func identifierName(node IdentifierNode) string {
+	index, err := strconv.Atoi(node.Token.Text)
+	if err != nil || index < 0 || index >= len(Global.GlobalVarNames) {
+		return node.Token.Text
+	}
+	return Global.GlobalVarNames[index]
+}
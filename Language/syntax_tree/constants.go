@@ -0,0 +1,56 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+)
+
+// Declares a variable which can't be given a new value or deleted, e.g. const LIMIT := 100
+type ConstantNode struct {
+	Token tokenizer.Token
+	Right Node
+}
+
+func (node ConstantNode) Evaluate() (Value, error) {
+	assignment, identifier, err := node.assignment()
+	if err != nil {
+		return Value{}, err
+	}
+	if _, err := assignment.Evaluate(); err != nil {
+		return Value{}, err
+	}
+	currentScope.constants[identifierName(identifier)] = true
+	return Value{}, nil
+}
+
+func (node ConstantNode) assignment() (AssignmentNode, IdentifierNode, error) {
+	assignment, ok := node.Right.(AssignmentNode)
+	if ok {
+		if identifier, err := assignedIdentifier(assignment.Left, assignment.Token); err == nil {
+			return assignment, identifier, nil
+		}
+	}
+	return AssignmentNode{}, IdentifierNode{}, LanErrs.AssignmentSyntaxError{Token: node.Token,
+		Reason: "const must be followed by a name, := and a value"}
+}
+
+// Stands for a value which is already known, the checker puts it in an operation to work out the value of a constant
+type foldedNode struct {
+	Token tokenizer.Token
+	value Value
+}
+
+func (node foldedNode) Evaluate() (Value, error) {
+	return node.value, nil
+}
+
+// The values of constants, by where their names are used, which can be used instead of looking the names up.
+// Check works them out for constants whose values are made only from literals, such as const LIMIT := 100 or
+// const DAY := 60 * 60 * 24
+type Folds map[tokenizer.Token]Value
+
+// Has the names in the global scope, and every scope made inside it, give the values of the constants they
+// refer to without looking them up
+func Fold(folds Folds) {
+	currentScope.folds = folds
+}
@@ -0,0 +1,297 @@
+package tree
+
+import (
+	"errors"
+	"language/LanErrs"
+	"language/tokenizer"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The csv module. Files are found in the same way as the file builtins, so they must be inside the file root.
+// Every field is read as a string
+func newCSVModule() Value {
+	members := map[string]Value{}
+	for _, f := range []function{
+		{name: "read", minArgs: 1, maxArgs: 4, named: []string{"header", "delimiter", "quote"}, call: csvRead},
+		{name: "write", minArgs: 2, maxArgs: 4, named: []string{"delimiter", "quote"}, call: csvWrite},
+	} {
+		members[f.name] = functionValue(function{name: "csv." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, named: f.named,
+			call: f.call})
+	}
+	return moduleValue("csv", members)
+}
+
+// The characters which split fields and quote them. quote is 0 when fields are never quoted
+type csvFormat struct {
+	delimiter rune
+	quote     rune
+}
+
+// Reads the delimiter and quote arguments which start at index first, they default to a comma and a double quote
+func csvFormatArgs(args []Value, first int, function string, token tokenizer.Token) (csvFormat, error) {
+	format := csvFormat{delimiter: ',', quote: '"'}
+	if _, ok := optionalArg(args, first); ok {
+		delimiter, err := stringArg(args, first, function, token)
+		if err != nil {
+			return csvFormat{}, err
+		}
+		if utf8.RuneCountInString(delimiter) != 1 || strings.ContainsAny(delimiter, "\r\n") {
+			return csvFormat{}, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "one character for the delimiter",
+				Got: describeValue(args[first])}
+		}
+		format.delimiter, _ = utf8.DecodeRuneInString(delimiter)
+	}
+	if _, ok := optionalArg(args, first+1); ok {
+		quote, err := stringArg(args, first+1, function, token)
+		if err != nil {
+			return csvFormat{}, err
+		}
+		if utf8.RuneCountInString(quote) > 1 || strings.ContainsAny(quote, "\r\n"+string(format.delimiter)) {
+			return csvFormat{}, LanErrs.WrongArgumentError{Token: token, Function: function,
+				Expected: "one character, which isn't the delimiter, or \"\" for the quote", Got: describeValue(args[first+1])}
+		}
+		format.quote, _ = utf8.DecodeRuneInString(quote)
+		if quote == "" {
+			format.quote = 0
+		}
+	}
+	return format, nil
+}
+
+// read(path) gives a list with a list of fields for each row. read(path, header=true) uses the first row as the
+// keys of a map for each of the other rows
+func csvRead(args []Value, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, "csv.read", token)
+	if err != nil {
+		return Value{}, err
+	}
+	header := false
+	if value, ok := optionalArg(args, 1); ok {
+		if value.ValueType != Bool {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "csv.read", Expected: "a bool for header",
+				Got: kindName(value.ValueType)}
+		}
+		header = value.Value == 1
+	}
+	format, err := csvFormatArgs(args, 2, "csv.read", token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	text, err := os.ReadFile(real)
+	if err != nil {
+		return Value{}, fileError(path, err, token)
+	}
+	rows, line, err := parseCSV(string(text), format)
+	if err != nil {
+		return Value{}, LanErrs.CSVError{Token: token, Path: path, Line: line, Reason: err.Error()}
+	}
+
+	if !header {
+		items := make([]Value, len(rows))
+		for i, row := range rows {
+			items[i] = stringList(row.fields)
+		}
+		return listValue(items), nil
+	}
+
+	items := []Value{}
+	if len(rows) == 0 {
+		return listValue(items), nil
+	}
+	keys := rows[0].fields
+	for _, row := range rows[1:] {
+		if len(row.fields) != len(keys) {
+			return Value{}, LanErrs.CSVError{Token: token, Path: path, Line: row.line,
+				Reason: "the row has " + plural(len(row.fields), "field") + " but the header has " + plural(len(keys), "field")}
+		}
+		m := newOrderedMap()
+		for i, key := range keys {
+			m.set(key, newString(row.fields[i]))
+		}
+		items = append(items, mapValue(m))
+	}
+	return listValue(items), nil
+}
+
+func stringList(texts []string) Value {
+	items := make([]Value, len(texts))
+	for i, text := range texts {
+		items[i] = newString(text)
+	}
+	return listValue(items)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return strconv.Itoa(n) + " " + word + "s"
+}
+
+// A row of a CSV file and the line it starts on
+type csvRow struct {
+	line   int
+	fields []string
+}
+
+// Splits CSV text into rows. A quoted field can hold the delimiter, new lines and the quote written twice.
+// Blank lines are skipped. When the text is invalid the line it went wrong on is given with the error
+func parseCSV(text string, format csvFormat) ([]csvRow, int, error) {
+	runes := []rune(strings.ReplaceAll(text, "\r\n", "\n"))
+	var rows []csvRow
+	var row *csvRow
+	var field strings.Builder
+	line := 1
+
+	for i := 0; i < len(runes); {
+		if row == nil {
+			if runes[i] == '\n' {
+				line++
+				i++
+				continue
+			}
+			row = &csvRow{line: line}
+		}
+
+		if format.quote != 0 && runes[i] == format.quote {
+			start := line
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, start, errors.New("a quoted field is never closed")
+				}
+				if runes[i] == format.quote {
+					if i+1 < len(runes) && runes[i+1] == format.quote {
+						field.WriteRune(format.quote)
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				field.WriteRune(runes[i])
+			}
+			if i < len(runes) && runes[i] != format.delimiter && runes[i] != '\n' {
+				return nil, line, errors.New("there is text after the closing quote of a field")
+			}
+		} else {
+			for ; i < len(runes) && runes[i] != format.delimiter && runes[i] != '\n'; i++ {
+				field.WriteRune(runes[i])
+			}
+		}
+
+		row.fields = append(row.fields, field.String())
+		field.Reset()
+		if i < len(runes) && runes[i] == format.delimiter {
+			i++
+			if i == len(runes) || runes[i] == '\n' {
+				row.fields = append(row.fields, "")
+			}
+			if i < len(runes) && runes[i] != '\n' {
+				continue
+			}
+		}
+		rows = append(rows, *row)
+		row = nil
+		line++
+		i++
+	}
+	return rows, line, nil
+}
+
+// write(path, rows) makes the file, or replaces everything in it. Rows can be lists of values or maps, the keys
+// of the first map are written as the header and every map must have them
+func csvWrite(args []Value, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, "csv.write", token)
+	if err != nil {
+		return Value{}, err
+	}
+	if args[1].ValueType != List {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "csv.write", Expected: "a list of rows",
+			Got: kindName(args[1].ValueType)}
+	}
+	format, err := csvFormatArgs(args, 2, "csv.write", token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	rows := lists[args[1].Value]
+	useHeader := len(rows) > 0 && rows[0].ValueType == Map
+	var header []string
+	var out strings.Builder
+	if useHeader {
+		header = maps[rows[0].Value].keys
+		if err := writeCSVRow(&out, header, format, token); err != nil {
+			return Value{}, err
+		}
+	}
+	for _, row := range rows {
+		var fields []string
+		switch {
+		case row.ValueType == List && !useHeader:
+			for _, item := range lists[row.Value] {
+				fields = append(fields, csvField(item))
+			}
+		case row.ValueType == Map && useHeader:
+			m := maps[row.Value]
+			for _, key := range header {
+				value, ok := m.values[key]
+				if !ok {
+					return Value{}, LanErrs.NoKeyError{Token: token, Key: key}
+				}
+				fields = append(fields, csvField(value))
+			}
+		default:
+			expected := "a list for each row"
+			if useHeader {
+				expected = "a map for each row"
+			}
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "csv.write", Expected: expected,
+				Got: kindName(row.ValueType)}
+		}
+		if err := writeCSVRow(&out, fields, format, token); err != nil {
+			return Value{}, err
+		}
+	}
+
+	return Value{ValueType: Nil}, writeText(path, real, out.String(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, token)
+}
+
+// nil is written as an empty field
+func csvField(v Value) string {
+	if v.ValueType == Nil {
+		return ""
+	}
+	return valueString(v)
+}
+
+// Fields with the delimiter, the quote or a new line in them are quoted
+func writeCSVRow(out *strings.Builder, fields []string, format csvFormat, token tokenizer.Token) error {
+	special := "\r\n" + string(format.delimiter)
+	if format.quote != 0 {
+		special += string(format.quote)
+	}
+	for i, field := range fields {
+		if i > 0 {
+			out.WriteRune(format.delimiter)
+		}
+		if !strings.ContainsAny(field, special) {
+			out.WriteString(field)
+			continue
+		}
+		if format.quote == 0 {
+			return LanErrs.WrongArgumentError{Token: token, Function: "csv.write",
+				Expected: "fields without the delimiter or new lines when quote is \"\"", Got: describeValue(newString(field))}
+		}
+		q := string(format.quote)
+		out.WriteString(q + strings.ReplaceAll(field, q, q+q) + q)
+	}
+	out.WriteString("\n")
+	return nil
+}
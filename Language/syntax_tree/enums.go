@@ -0,0 +1,72 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+)
+
+// The names of every enum are kept in this list and the Value holds their index, so two enum values are equal
+// when they are the same name from the same declaration
+var enumMembers []enumMember
+
+type enumMember struct {
+	enum string
+	name string
+}
+
+// Declares an enum, e.g. enum Status { Active, Suspended }. The enum is a module holding a value for each name,
+// used as Status.Active
+type EnumNode struct {
+	Token      tokenizer.Token
+	Name       Node
+	Statements []Node
+}
+
+func (node EnumNode) Evaluate() (Value, error) {
+	identifier, names, err := node.members()
+	if err != nil {
+		return Value{}, err
+	}
+	name := identifierName(identifier)
+	if _, ok := currentScope.vars[name]; ok {
+		return Value{}, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: name}
+	}
+
+	members := make(map[string]Value, len(names))
+	for _, member := range names {
+		enumMembers = append(enumMembers, enumMember{enum: name, name: member})
+		members[member] = Value{ValueType: Enum, Value: uint64(len(enumMembers) - 1)}
+	}
+	currentScope.vars[name] = moduleValue(name, members)
+	return Value{}, nil
+}
+
+// Gives the name of the enum and its names in the order they were written
+func (node EnumNode) members() (IdentifierNode, []string, error) {
+	identifier, ok := node.Name.(IdentifierNode)
+	if !ok {
+		return IdentifierNode{}, nil, LanErrs.EnumSyntaxError{Token: node.Token, Reason: "enum must be followed by a name"}
+	}
+	var names []string
+	for _, statement := range node.Statements {
+		member, ok := statement.(IdentifierNode)
+		if !ok {
+			return identifier, nil, LanErrs.EnumSyntaxError{Token: node.Token, Reason: "the block can only have names"}
+		}
+		for _, name := range names {
+			if name == identifierName(member) {
+				return identifier, nil, LanErrs.EnumSyntaxError{Token: member.Token, Reason: "\"" + name + "\" is declared more than once"}
+			}
+		}
+		names = append(names, identifierName(member))
+	}
+	if len(names) == 0 {
+		return identifier, nil, LanErrs.EnumSyntaxError{Token: node.Token, Reason: "it must have at least one name"}
+	}
+	return identifier, names, nil
+}
+
+// An enum value is written with the name of its enum, e.g. Status.Active
+func (m enumMember) String() string {
+	return m.enum + "." + m.name
+}
@@ -0,0 +1,120 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+)
+
+// Errors caught by catch are kept in this list and the Value holds their index
+var caughtErrors []caughtError
+
+// An error given to a catch block. value is the value given to throw, or nil when the error came from the language
+type caughtError struct {
+	err   error
+	value Value
+}
+
+func errorValue(err error, value Value) Value {
+	caughtErrors = append(caughtErrors, caughtError{err: err, value: value})
+	return Value{ValueType: Error, Value: uint64(len(caughtErrors) - 1)}
+}
+
+// The fields of a caught error, used with a . e.g. err.message. code is the name of the LanErrs type, line and
+// column are nil when the error doesn't have a position, e.g. an error from an imported module
+func (e caughtError) member(name string) (Value, bool) {
+	switch name {
+	case "message":
+		if thrown, ok := e.err.(LanErrs.ThrownError); ok {
+			return newString(thrown.Message), true
+		}
+		return newString(e.err.Error()), true
+	case "code":
+		if coded, ok := e.err.(LanErrs.Coded); ok {
+			return newString(coded.Code()), true
+		}
+		return newString(""), true
+	case "value":
+		return e.value, true
+	case "line", "column":
+		positioned, ok := e.err.(LanErrs.Positioned)
+		if !ok {
+			return Value{ValueType: Nil}, true
+		}
+		token := positioned.Position()
+		if name == "line" {
+			return intValue(token.LineNum), true
+		}
+		return intValue(token.Cursor), true
+	}
+	return Value{}, false
+}
+
+// Runs the statements and, if one gives an error, runs the catch block with the error in ErrName.
+// The finally block is always run last. A return from inside the statements isn't an error so it isn't caught
+type TryNode struct {
+	Token      tokenizer.Token
+	Statements []Node
+	Caught     bool
+	ErrName    Node
+	Catch      []Node
+	Finally    []Node
+}
+
+func (node TryNode) Evaluate() (Value, error) {
+	err := evaluateBlock(node.Statements)
+	if _, isReturn := err.(returned); err != nil && !isReturn && node.Caught {
+		err = node.evaluateCatch(err)
+	}
+	if node.Finally != nil {
+		if err := evaluateBlock(node.Finally); err != nil {
+			return Value{}, err
+		}
+	}
+	return Value{}, err
+}
+
+func (node TryNode) evaluateCatch(err error) error {
+	pushScope()
+	defer popScope()
+
+	if identifier, ok := node.ErrName.(IdentifierNode); ok {
+		value := Value{ValueType: Nil}
+		if thrown, ok := err.(thrownValue); ok {
+			value, err = thrown.value, thrown.ThrownError
+		}
+		currentScope.vars[identifierName(identifier)] = errorValue(err, value)
+	}
+	for _, statement := range node.Catch {
+		if _, err := statement.Evaluate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An error made by throw which still has the value it was given
+type thrownValue struct {
+	LanErrs.ThrownError
+	value Value
+}
+
+// Gives an error which can be caught. Throwing a caught error throws it again unchanged
+type ThrowNode struct {
+	Token tokenizer.Token
+	Right Node
+}
+
+func (node ThrowNode) Evaluate() (Value, error) {
+	value, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	if value.ValueType == Error {
+		caught := caughtErrors[value.Value]
+		if thrown, ok := caught.err.(LanErrs.ThrownError); ok {
+			return Value{}, thrownValue{ThrownError: thrown, value: caught.value}
+		}
+		return Value{}, caught.err
+	}
+	return Value{}, thrownValue{ThrownError: LanErrs.ThrownError{Token: node.Token, Message: valueString(value)}, value: value}
+}
@@ -0,0 +1,221 @@
+package tree
+
+import (
+	"errors"
+	"io/fs"
+	"language/LanErrs"
+	"language/tokenizer"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Turns a path used in a program into a real path inside the file root. Relative paths start at the root.
+// Paths which leave the root, either with .. or through a symlink, give a PermissionError
+func resolvePath(path string, token tokenizer.Token) (string, error) {
+	if settings.FileRoot == "" {
+		return "", LanErrs.FileError{Token: token, Path: path, Reason: "files can't be used without interpreter.WithFileRoot"}
+	}
+	root, err := filepath.Abs(settings.FileRoot)
+	if err != nil {
+		return "", LanErrs.FileError{Token: token, Path: settings.FileRoot, Reason: err.Error()}
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fileError(settings.FileRoot, err, token)
+	}
+
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(root, full)
+	}
+	full = filepath.Clean(full)
+	if !isInside(root, full) && !isInside(realRoot, full) {
+		return "", LanErrs.PermissionError{Token: token, Path: path}
+	}
+
+	real, err := evalExistingSymlinks(full)
+	if err != nil {
+		return "", fileError(path, err, token)
+	}
+	if !isInside(realRoot, real) {
+		return "", LanErrs.PermissionError{Token: token, Path: path}
+	}
+	return real, nil
+}
+
+var errDanglingSymlink = errors.New("it is a symlink to something which doesn't exist")
+
+// Follows the symlinks in the part of the path which exists, so a file which is about to be made is checked
+// against the directory it will be made in. A symlink to something which doesn't exist isn't followed, as it could
+// point outside the root and the file would be made there
+func evalExistingSymlinks(path string) (string, error) {
+	real, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return real, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return "", errDanglingSymlink
+	}
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+	realParent, err := evalExistingSymlinks(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(realParent, filepath.Base(path)), nil
+}
+
+func isInside(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func fileError(path string, err error, token tokenizer.Token) error {
+	reason := err.Error()
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		reason = "it doesn't exist"
+	case errors.As(err, &pathErr):
+		reason = pathErr.Err.Error()
+	}
+	return LanErrs.FileError{Token: token, Path: path, Reason: reason}
+}
+
+// Gets the path given to a file builtin and finds where it is inside the file root
+func pathArg(args []Value, function string, token tokenizer.Token) (string, string, error) {
+	path, err := stringArg(args, 0, function, token)
+	if err != nil {
+		return "", "", err
+	}
+	real, err := resolvePath(path, token)
+	return path, real, err
+}
+
+func readFile(args []Value, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, "readFile", token)
+	if err != nil {
+		return Value{}, err
+	}
+	text, err := os.ReadFile(real)
+	if err != nil {
+		return Value{}, fileError(path, err, token)
+	}
+	return newString(string(text)), nil
+}
+
+// Makes the file or replaces everything in it
+func writeFile(args []Value, token tokenizer.Token) (Value, error) {
+	return saveFile(args, "writeFile", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, token)
+}
+
+// Makes the file or adds to the end of it
+func appendFile(args []Value, token tokenizer.Token) (Value, error) {
+	return saveFile(args, "appendFile", os.O_CREATE|os.O_WRONLY|os.O_APPEND, token)
+}
+
+func saveFile(args []Value, function string, flags int, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, function, token)
+	if err != nil {
+		return Value{}, err
+	}
+	text, err := stringArg(args, 1, function, token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return Value{ValueType: Nil}, writeText(path, real, text, flags, token)
+}
+
+// Writes to a path from resolvePath. The path could have been changed to go through a symlink after it was
+// checked, so the file which was opened is checked to still be the one at the path before anything is written
+// or emptied
+func writeText(path string, real string, text string, flags int, token tokenizer.Token) error {
+	file, err := os.OpenFile(real, flags&^os.O_TRUNC, 0644)
+	if err != nil {
+		return fileError(path, err, token)
+	}
+	opened, statErr := file.Stat()
+	found, lstatErr := os.Lstat(real)
+	resolved, evalErr := filepath.EvalSymlinks(real)
+	if statErr != nil || lstatErr != nil || evalErr != nil || !os.SameFile(opened, found) || resolved != real {
+		file.Close()
+		return LanErrs.PermissionError{Token: token, Path: path}
+	}
+
+	if flags&os.O_TRUNC != 0 {
+		if err := file.Truncate(0); err != nil {
+			file.Close()
+			return fileError(path, err, token)
+		}
+	}
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return fileError(path, err, token)
+	}
+	if err := file.Close(); err != nil {
+		return fileError(path, err, token)
+	}
+	return nil
+}
+
+// A list of the lines in the file without their line endings
+func readLines(args []Value, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, "readLines", token)
+	if err != nil {
+		return Value{}, err
+	}
+	text, err := os.ReadFile(real)
+	if err != nil {
+		return Value{}, fileError(path, err, token)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(text), "\r\n", "\n"), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	items := make([]Value, len(lines))
+	for i, line := range lines {
+		items[i] = newString(line)
+	}
+	return listValue(items), nil
+}
+
+func exists(args []Value, token tokenizer.Token) (Value, error) {
+	_, real, err := pathArg(args, "exists", token)
+	if err != nil {
+		return Value{}, err
+	}
+	_, err = os.Stat(real)
+	return boolValue(err == nil), nil
+}
+
+// A list of the names in a directory in alphabetical order
+func listDir(args []Value, token tokenizer.Token) (Value, error) {
+	path, real, err := pathArg(args, "listDir", token)
+	if err != nil {
+		return Value{}, err
+	}
+	entries, err := os.ReadDir(real)
+	if err != nil {
+		return Value{}, fileError(path, err, token)
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	sort.Strings(names)
+	items := make([]Value, len(names))
+	for i, name := range names {
+		items[i] = newString(name)
+	}
+	return listValue(items), nil
+}
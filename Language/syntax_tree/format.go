@@ -0,0 +1,228 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+	"strings"
+)
+
+// How a value is written by a {} field in format, e.g. {:>8.2f}
+type formatSpec struct {
+	fill      rune
+	align     rune
+	zeroPad   bool
+	width     int
+	precision int
+	kind      rune
+}
+
+// Builds a string by putting values into the {} fields of a template, e.g. format("{:.2f} items", x).
+// {{ and }} write a single brace. The fields are either all numbered or all left to count up from 0
+func format(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != str {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "format", Expected: "a string", Got: kindName(args[0].ValueType)}
+	}
+	template := []rune(Global.Strings[args[0].Value])
+	values := args[1:]
+
+	var builder strings.Builder
+	next := 0
+	numbered, counted := false, false
+	for i := 0; i < len(template); i++ {
+		switch {
+		case template[i] == '{' && i+1 < len(template) && template[i+1] == '{',
+			template[i] == '}' && i+1 < len(template) && template[i+1] == '}':
+			builder.WriteRune(template[i])
+			i++
+
+		case template[i] == '}':
+			return Value{}, LanErrs.FormatError{Token: token, Reason: "\"}\" has no \"{\" before it"}
+
+		case template[i] == '{':
+			end := i + 1
+			for end < len(template) && template[end] != '}' {
+				end++
+			}
+			if end == len(template) {
+				return Value{}, LanErrs.FormatError{Token: token, Reason: "\"{\" is never closed"}
+			}
+			field := string(template[i+1 : end])
+
+			index, specText := next, ""
+			if colon := strings.IndexRune(field, ':'); colon >= 0 {
+				field, specText = field[:colon], field[colon+1:]
+			}
+			if field != "" {
+				n, err := strconv.Atoi(field)
+				if err != nil || n < 0 {
+					return Value{}, LanErrs.FormatError{Token: token, Reason: "\"{" + field + "}\" is not a field number"}
+				}
+				index = n
+				numbered = true
+			} else {
+				next++
+				counted = true
+			}
+			if numbered && counted {
+				return Value{}, LanErrs.FormatError{Token: token, Reason: "numbered fields and {} can't both be used"}
+			}
+			if index >= len(values) {
+				return Value{}, LanErrs.FormatError{Token: token, Reason: "there is no value for field " + strconv.Itoa(index)}
+			}
+
+			spec, err := parseFormatSpec(specText, token)
+			if err != nil {
+				return Value{}, err
+			}
+			text, err := formatValue(values[index], spec, token)
+			if err != nil {
+				return Value{}, err
+			}
+			builder.WriteString(text)
+			i = end
+
+		default:
+			builder.WriteRune(template[i])
+		}
+	}
+	return newString(builder.String()), nil
+}
+
+// The biggest width or precision a field can have
+const maxFormatSize = 10000
+
+// Reads [[fill]align][0][width][.precision][type] where align is <, > or ^ and type is f, d or s
+func parseFormatSpec(text string, token tokenizer.Token) (formatSpec, error) {
+	unknown := LanErrs.FormatError{Token: token, Reason: "\"" + text + "\" is not a known format"}
+	spec := formatSpec{fill: ' ', precision: -1}
+	runes := []rune(text)
+	i := 0
+
+	isAlign := func(r rune) bool { return r == '<' || r == '>' || r == '^' }
+	switch {
+	case len(runes) >= 2 && isAlign(runes[1]):
+		spec.fill, spec.align = runes[0], runes[1]
+		i = 2
+	case len(runes) >= 1 && isAlign(runes[0]):
+		spec.align = runes[0]
+		i = 1
+	}
+
+	if i < len(runes) && runes[i] == '0' {
+		spec.zeroPad = true
+		i++
+	}
+	start := i
+	for i < len(runes) && runes[i] >= '0' && runes[i] <= '9' {
+		i++
+	}
+	if i > start {
+		width, err := formatSize(string(runes[start:i]), "width", token)
+		if err != nil {
+			return formatSpec{}, err
+		}
+		spec.width = width
+	}
+
+	if i < len(runes) && runes[i] == '.' {
+		i++
+		start = i
+		for i < len(runes) && runes[i] >= '0' && runes[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return formatSpec{}, unknown
+		}
+		precision, err := formatSize(string(runes[start:i]), "precision", token)
+		if err != nil {
+			return formatSpec{}, err
+		}
+		spec.precision = precision
+	}
+
+	if i < len(runes) && (runes[i] == 'f' || runes[i] == 'd' || runes[i] == 's') {
+		spec.kind = runes[i]
+		i++
+	}
+	if i != len(runes) {
+		return formatSpec{}, unknown
+	}
+	return spec, nil
+}
+
+// Reads the digits of a width or precision, which can be at most maxFormatSize
+func formatSize(digits string, name string, token tokenizer.Token) (int, error) {
+	size, err := strconv.Atoi(digits)
+	if err != nil || size > maxFormatSize {
+		return 0, LanErrs.FormatError{Token: token, Reason: "the " + name + " " + digits + " is bigger than " + strconv.Itoa(maxFormatSize)}
+	}
+	return size, nil
+}
+
+func formatValue(v Value, spec formatSpec, token tokenizer.Token) (string, error) {
+	number := isNum(v.ValueType)
+	wrongKind := func(expected string) error {
+		return LanErrs.FormatError{Token: token, Reason: "{:" + string(spec.kind) + "} needs " + expected + " but was given " + kindName(v.ValueType)}
+	}
+
+	var text string
+	switch {
+	case spec.kind == 'd':
+		if !isInt(v.ValueType) {
+			return "", wrongKind("an int")
+		}
+		text = valueString(v)
+
+	case spec.kind == 'f' || number && spec.precision >= 0:
+		if !number {
+			return "", wrongKind("a number")
+		}
+		precision := spec.precision
+		if precision < 0 {
+			precision = 6
+		}
+		if v.ValueType == Float {
+			text = strconv.FormatFloat(FloatUncast(v.Value), 'f', precision, 64)
+		} else {
+			text = asDecimal(v).StringFixed(precision)
+		}
+
+	default:
+		text = valueString(v)
+		if spec.precision >= 0 && len([]rune(text)) > spec.precision {
+			text = string([]rune(text)[:spec.precision])
+		}
+	}
+
+	padding := spec.width - len([]rune(text))
+	if padding <= 0 {
+		return text, nil
+	}
+
+	// Zeros go after the sign of a number, e.g. -0042
+	if spec.zeroPad && spec.align == 0 && number {
+		sign := ""
+		if strings.HasPrefix(text, "-") {
+			sign, text = "-", text[1:]
+		}
+		return sign + strings.Repeat("0", padding) + text, nil
+	}
+
+	align := spec.align
+	if align == 0 {
+		align = '<'
+		if number {
+			align = '>'
+		}
+	}
+	fill := string(spec.fill)
+	switch align {
+	case '>':
+		return strings.Repeat(fill, padding) + text, nil
+	case '^':
+		return strings.Repeat(fill, padding/2) + text + strings.Repeat(fill, padding-padding/2), nil
+	}
+	return text + strings.Repeat(fill, padding), nil
+}
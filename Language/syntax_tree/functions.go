@@ -0,0 +1,286 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+	"strings"
+)
+
+// How many calls to functions written in the program can be inside each other before it is an error
+const maxCallDepth = 5000
+
+var callDepth = 0
+
+// Makes a function from the program. Each call runs in a new scope inside closure, the scope the function was
+// made in, so it can use and change the variables around it even after that scope has finished
+func userFunction(name string, params []string, closure *scope, run func() (Value, error)) Value {
+	return functionValue(function{name: name, minArgs: len(params), maxArgs: len(params),
+		call: func(args []Value, token tokenizer.Token) (Value, error) {
+			if callDepth >= maxCallDepth {
+				return Value{}, LanErrs.RecursionError{Token: token, Depth: maxCallDepth}
+			}
+			callDepth++
+			saved := currentScope
+			currentScope = newScope(closure)
+			defer func() {
+				currentScope = saved
+				callDepth--
+			}()
+
+			for i, param := range params {
+				currentScope.vars[param] = args[i]
+			}
+			value, err := run()
+			if r, ok := err.(returned); ok {
+				return r.value, nil
+			}
+			return value, err
+		}})
+}
+
+// Gets the names of the parameters from the head of a function, e.g. add(a, b) or fn(a, b)
+func parameterNames(head CallNode) ([]string, error) {
+	names := make([]string, len(head.Args))
+	for i, arg := range head.Args {
+		identifier, ok := arg.(IdentifierNode)
+		if !ok {
+			return nil, LanErrs.FunctionSyntaxError{Token: head.Token, Reason: "parameters must be names"}
+		}
+		names[i] = identifierName(identifier)
+		for _, name := range names[:i] {
+			if name == names[i] {
+				return nil, LanErrs.FunctionSyntaxError{Token: identifier.Token, Reason: "\"" + name + "\" is used for two parameters"}
+			}
+		}
+	}
+	return names, nil
+}
+
+// Declares a function, e.g. fn add(a, b) { }. The function is put in a variable with its name in the current
+// scope, so it can call itself
+type FunctionNode struct {
+	Token      tokenizer.Token
+	Head       Node
+	Statements []Node
+}
+
+func (node FunctionNode) Evaluate() (Value, error) {
+	name, params, err := node.signature()
+	if err != nil {
+		return Value{}, err
+	}
+	if _, ok := currentScope.vars[identifierName(name)]; ok {
+		return Value{}, LanErrs.AlreadyDeclaredError{Token: name.Token, Identifier: identifierName(name)}
+	}
+
+	f := userFunction(identifierName(name), params, currentScope, node.run)
+	currentScope.vars[identifierName(name)] = f
+	return f, nil
+}
+
+// Runs the statements of the function, it gives back nil unless a return ends it
+func (node FunctionNode) run() (Value, error) {
+	for _, statement := range node.Statements {
+		if _, err := statement.Evaluate(); err != nil {
+			return Value{}, err
+		}
+	}
+	return Value{ValueType: Nil}, nil
+}
+
+// Gives the name of the function and the names of its parameters
+func (node FunctionNode) signature() (IdentifierNode, []string, error) {
+	head, ok := node.Head.(CallNode)
+	name, isName := head.Callee.(IdentifierNode)
+	if !ok || !isName {
+		return IdentifierNode{}, nil, LanErrs.FunctionSyntaxError{Token: node.Token,
+			Reason: "fn must be followed by a name and the parameters in brackets"}
+	}
+	params, err := parameterNames(head)
+	return name, params, err
+}
+
+// The fn which starts a lambda, it is only used in the head of a LambdaNode
+type FnNode struct {
+	Token tokenizer.Token
+}
+
+func (node FnNode) Evaluate() (Value, error) {
+	return Value{}, LanErrs.FunctionSyntaxError{Token: node.Token, Reason: "fn(...) must be followed by => and what it gives back"}
+}
+
+// A function without a name which gives back the value of one expression, e.g. fn(x) => x * 2
+type LambdaNode struct {
+	Token tokenizer.Token
+	Head  Node
+	Body  Node
+}
+
+func (node LambdaNode) Evaluate() (Value, error) {
+	params, err := node.parameters()
+	if err != nil {
+		return Value{}, err
+	}
+	return userFunction("lambda", params, currentScope, node.Body.Evaluate), nil
+}
+
+func (node LambdaNode) parameters() ([]string, error) {
+	if head, ok := node.Head.(CallNode); ok {
+		if _, ok := head.Callee.(FnNode); ok {
+			return parameterNames(head)
+		}
+	}
+	return nil, LanErrs.FunctionSyntaxError{Token: node.Token, Reason: "=> must come after fn and the parameters in brackets"}
+}
+
+// Ends the function it is in and gives back the value
+type ReturnNode struct {
+	Token tokenizer.Token
+	Right Node
+}
+
+func (node ReturnNode) Evaluate() (Value, error) {
+	value, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{}, returned{ReturnOutsideFunctionError: LanErrs.ReturnOutsideFunctionError{Token: node.Token}, value: value}
+}
+
+// Passed up through the statements like an error until the function call catches it. It is only shown when
+// return is used outside of a function, and it can't be caught by try
+type returned struct {
+	LanErrs.ReturnOutsideFunctionError
+	value Value
+}
+
+// Calls a function given to a builtin such as map
+func callFunction(f Value, args []Value, function string, token tokenizer.Token) (Value, error) {
+	if f.ValueType != Function {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a function", Got: kindName(f.ValueType)}
+	}
+	callee := functions[f.Value]
+	if err := callee.checkArgs(len(args), token); err != nil {
+		return Value{}, err
+	}
+	return callee.call(args, token)
+}
+
+// The items of a list, or each character of a string as a string
+func sequenceItems(v Value, function string, token tokenizer.Token) ([]Value, error) {
+	switch v.ValueType {
+	case List:
+		return append([]Value{}, lists[v.Value]...), nil
+	case str:
+		items := []Value{}
+		for _, char := range Global.Strings[v.Value] {
+			items = append(items, newString(string(char)))
+		}
+		return items, nil
+	}
+	return nil, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a string or list", Got: kindName(v.ValueType)}
+}
+
+// map(f, items) gives a list of f(item) for each item
+func mapItems(args []Value, token tokenizer.Token) (Value, error) {
+	items, err := sequenceItems(args[1], "map", token)
+	if err != nil {
+		return Value{}, err
+	}
+	for i, item := range items {
+		if items[i], err = callFunction(args[0], []Value{item}, "map", token); err != nil {
+			return Value{}, err
+		}
+	}
+	return listValue(items), nil
+}
+
+// filter(f, items) keeps the items where f(item) is true. A string gives a string of the characters kept
+func filterItems(args []Value, token tokenizer.Token) (Value, error) {
+	items, err := sequenceItems(args[1], "filter", token)
+	if err != nil {
+		return Value{}, err
+	}
+	kept := []Value{}
+	for _, item := range items {
+		keep, err := callFunction(args[0], []Value{item}, "filter", token)
+		if err != nil {
+			return Value{}, err
+		}
+		if keep.ValueType != Bool {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "filter", Expected: "a function which gives a bool",
+				Got: "one which gave " + kindName(keep.ValueType)}
+		}
+		if keep.Value == 1 {
+			kept = append(kept, item)
+		}
+	}
+
+	if args[1].ValueType == str {
+		var out strings.Builder
+		for _, item := range kept {
+			out.WriteString(Global.Strings[item.Value])
+		}
+		return newString(out.String()), nil
+	}
+	return listValue(kept), nil
+}
+
+// reduce(f, items, start) gives f(f(start, item0), item1) and so on. Without start the first item is used
+func reduceItems(args []Value, token tokenizer.Token) (Value, error) {
+	items, err := sequenceItems(args[1], "reduce", token)
+	if err != nil {
+		return Value{}, err
+	}
+	var total Value
+	if len(args) == 3 {
+		total = args[2]
+	} else {
+		if len(items) == 0 {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "reduce", Expected: "a start value or at least one item",
+				Got: describeValue(args[1])}
+		}
+		total, items = items[0], items[1:]
+	}
+	for _, item := range items {
+		if total, err = callFunction(args[0], []Value{total, item}, "reduce", token); err != nil {
+			return Value{}, err
+		}
+	}
+	return total, nil
+}
+
+// range(end) gives the ints from 0 up to but not including end, range(start, end) starts at start and
+// range(start, end, step) goes up, or down, by step
+func intRange(args []Value, token tokenizer.Token) (Value, error) {
+	bounds := make([]int, len(args))
+	for i, arg := range args {
+		if arg.ValueType != Integer {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "range", Expected: "an int", Got: kindName(arg.ValueType)}
+		}
+		bounds[i] = intUncast(arg.Value)
+	}
+	start, end, step := 0, bounds[0], 1
+	if len(bounds) > 1 {
+		start, end = bounds[0], bounds[1]
+	}
+	if len(bounds) > 2 {
+		step = bounds[2]
+	}
+	if step == 0 {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "range", Expected: "a step which isn't 0",
+			Got: strconv.Itoa(step)}
+	}
+
+	items := []Value{}
+	for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+		items = append(items, intValue(i))
+		// The loop stops before the next step would go past the biggest or smallest int
+		if step > 0 && i >= end-step || step < 0 && i <= end-step {
+			break
+		}
+	}
+	return listValue(items), nil
+}
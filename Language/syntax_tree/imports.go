@@ -0,0 +1,151 @@
+package tree
+
+import (
+	"fmt"
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Modules which have been imported by the real path of their file, so each file is only run once
+var importedModules map[string]Value
+
+// A file which is being run, with its path written the way it was imported
+type importedFile struct {
+	real  string
+	shown string
+}
+
+// The files being run, from the program's file to the one running now. Imports are found relative to the last
+// one, and importing any of them again is a cyclic import
+var importChain []importedFile
+
+func resetImports() {
+	importedModules = map[string]Value{}
+	importChain = []importedFile{{real: "", shown: "the program"}}
+	if settings.ScriptPath != "" {
+		real, err := filepath.Abs(settings.ScriptPath)
+		if err == nil {
+			importChain[0] = importedFile{real: real, shown: settings.ScriptPath}
+		}
+	}
+}
+
+// Runs another file once and puts its top level variables in a module named after the file, e.g.
+// import "lib/util" gives util.helper
+type ImportNode struct {
+	Token tokenizer.Token
+	Right Node
+}
+
+func (node ImportNode) Evaluate() (Value, error) {
+	value, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	if value.ValueType != str {
+		return Value{}, LanErrs.WrongArgumentError{Token: node.Token, Function: "import", Expected: "a string path",
+			Got: kindName(value.ValueType)}
+	}
+	path := Global.Strings[value.Value]
+	name := moduleName(path)
+
+	real, err := findImport(path, node.Token)
+	if err != nil {
+		return Value{}, err
+	}
+	for i, file := range importChain {
+		if file.real == real {
+			var chain []string
+			for _, file := range importChain[i:] {
+				chain = append(chain, file.shown)
+			}
+			return Value{}, LanErrs.CyclicImportError{Token: node.Token, Chain: append(chain, path)}
+		}
+	}
+
+	module, ok := importedModules[real]
+	if !ok {
+		module, err = runModule(real, path, name, node.Token)
+		if err != nil {
+			return Value{}, err
+		}
+	}
+	//Importing the same module again does nothing, but it can't replace a different variable
+	if existing, ok := currentScope.vars[name]; ok && existing != module {
+		return Value{}, LanErrs.AlreadyDeclaredError{Token: node.Token, Identifier: name}
+	}
+	currentScope.vars[name] = module
+	return Value{}, nil
+}
+
+// Looks for the file next to the file doing the import and then in each directory of the import path
+func findImport(path string, token tokenizer.Token) (string, error) {
+	candidates := []string{path}
+	if !filepath.IsAbs(path) {
+		candidates = []string{filepath.Join(filepath.Dir(importChain[len(importChain)-1].real), path)}
+		for _, dir := range settings.ImportPath {
+			candidates = append(candidates, filepath.Join(dir, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		real, err := filepath.Abs(candidate)
+		if err != nil {
+			return "", LanErrs.ImportError{Token: token, Path: path, Reason: err.Error()}
+		}
+		return real, nil
+	}
+	return "", LanErrs.ImportError{Token: token, Path: path, Reason: "it isn't next to the importing file or in the import path"}
+}
+
+// The variable a module is put in, the name of its file without the extension
+func moduleName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// Runs a module in its own global scope. Errors found in it are given with the path of the module
+func runModule(real string, path string, name string, token tokenizer.Token) (Value, error) {
+	if settings.Parse == nil {
+		return Value{}, LanErrs.ImportError{Token: token, Path: path, Reason: "the interpreter can't read files"}
+	}
+	lines, errs := settings.Parse(real)
+	var folds Folds
+	if len(errs) == 0 {
+		var warnings []error
+		errs, warnings, folds = Check(lines)
+		if len(errs) == 0 && len(warnings) > 0 {
+			fmt.Fprintln(settings.Stdout, LanErrs.ModuleError{Path: path, Errs: warnings})
+		}
+	}
+	if len(errs) > 0 {
+		return Value{}, LanErrs.ModuleError{Path: path, Errs: errs}
+	}
+
+	importChain = append(importChain, importedFile{real: real, shown: path})
+	saved := currentScope
+	currentScope = newScope(builtinScope)
+	Fold(folds)
+	defer func() {
+		importChain = importChain[:len(importChain)-1]
+		currentScope = saved
+	}()
+
+	for _, line := range lines {
+		if _, err := line.Evaluate(); err != nil {
+			return Value{}, LanErrs.ModuleError{Path: path, Errs: []error{err}}
+		}
+	}
+
+	// The members are the variables of the module itself, so changes its functions make to them are seen
+	module := moduleValue(name, currentScope.vars)
+	importedModules[real] = module
+	return module, nil
+}
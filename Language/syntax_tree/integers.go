@@ -0,0 +1,118 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math"
+	"math/big"
+)
+
+// Ints are kept in the Value as a Go int until they get too big. They are then moved into bigInts and
+// the Value holds their index, in the same way strings are kept in Global.Strings
+var bigInts []*big.Int
+
+// Gives the Value for a big.Int, using a normal int when it fits. In strict mode ints never become big
+// and an error is given instead
+func bigIntValue(val *big.Int, token tokenizer.Token) (Value, error) {
+	if val.IsInt64() && val.Int64() >= math.MinInt && val.Int64() <= math.MaxInt {
+		return intValue(int(val.Int64())), nil
+	}
+	if settings.StrictIntegers {
+		return Value{}, LanErrs.IntegerOverflowError{Token: token}
+	}
+	bigInts = append(bigInts, val)
+	return Value{ValueType: BigInteger, Value: uint64(len(bigInts) - 1)}, nil
+}
+
+func asBigInt(v Value) *big.Int {
+	if v.ValueType == BigInteger {
+		return bigInts[v.Value]
+	}
+	return big.NewInt(int64(intUncast(v.Value)))
+}
+
+func isInt(v valueKind) bool {
+	if v == Integer || v == BigInteger {
+		return true
+	}
+	return false
+}
+
+func addInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType == Integer && right.ValueType == Integer {
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		if sum := l + r; (sum > l) == (r > 0) {
+			return intValue(sum), nil
+		}
+	}
+	return bigIntValue(new(big.Int).Add(asBigInt(left), asBigInt(right)), token)
+}
+
+func subtractInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType == Integer && right.ValueType == Integer {
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		if difference := l - r; (difference < l) == (r > 0) {
+			return intValue(difference), nil
+		}
+	}
+	return bigIntValue(new(big.Int).Sub(asBigInt(left), asBigInt(right)), token)
+}
+
+func multiplyInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType == Integer && right.ValueType == Integer {
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		if l == 0 || r == 0 {
+			return intValue(0), nil
+		}
+		product := l * r
+		if product/r == l && !(l == -1 && r == math.MinInt) && !(r == -1 && l == math.MinInt) {
+			return intValue(product), nil
+		}
+	}
+	return bigIntValue(new(big.Int).Mul(asBigInt(left), asBigInt(right)), token)
+}
+
+// The power must not be negative. The size of the answer is checked before it is worked out, as a big power
+// could take too long to work out
+func powerInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	digits := decimal.PowerDigits(asBigInt(left), asBigInt(right))
+	// The biggest int has 19 digits
+	if settings.StrictIntegers && digits.Cmp(big.NewInt(19)) > 0 {
+		return Value{}, LanErrs.IntegerOverflowError{Token: token}
+	}
+	if digits.Cmp(big.NewInt(decimal.MaxPowerDigits)) > 0 {
+		return Value{}, LanErrs.PowerTooBigError{Token: token}
+	}
+	return bigIntValue(new(big.Int).Exp(asBigInt(left), asBigInt(right), nil), token)
+}
+
+func negateInt(right Value, token tokenizer.Token) (Value, error) {
+	if right.ValueType == Integer && intUncast(right.Value) != math.MinInt {
+		return intValue(-intUncast(right.Value)), nil
+	}
+	return bigIntValue(new(big.Int).Neg(asBigInt(right)), token)
+}
+
+// Whole number division which rounds towards zero, the right side must not be zero
+func divideInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType == Integer && right.ValueType == Integer {
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		if !(l == math.MinInt && r == -1) {
+			return intValue(l / r), nil
+		}
+	}
+	return bigIntValue(new(big.Int).Quo(asBigInt(left), asBigInt(right)), token)
+}
+
+// The remainder from divideInts, the right side must not be zero
+func moduloInts(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType == Integer && right.ValueType == Integer {
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		if r == -1 {
+			return intValue(0), nil
+		}
+		return intValue(l % r), nil
+	}
+	return bigIntValue(new(big.Int).Rem(asBigInt(left), asBigInt(right)), token)
+}
@@ -0,0 +1,233 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The json module. Objects become maps which keep the order of their keys, arrays become lists and null is nil.
+// Whole numbers become ints and other numbers become decimals, so no number loses any digits
+func newJSONModule() Value {
+	members := map[string]Value{}
+	for _, f := range []function{
+		{name: "parse", minArgs: 1, maxArgs: 1, call: jsonParse},
+		{name: "stringify", minArgs: 1, maxArgs: 2, call: jsonStringify},
+	} {
+		members[f.name] = functionValue(function{name: "json." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, call: f.call})
+	}
+	return moduleValue("json", members)
+}
+
+func jsonParse(args []Value, token tokenizer.Token) (Value, error) {
+	text, err := stringArg(args, 0, "json.parse", token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	parser := jsonParser{decoder: decoder, text: text, token: token}
+	value, err := parser.value()
+	if err != nil {
+		return Value{}, err
+	}
+
+	end := decoder.InputOffset()
+	if _, err := decoder.Token(); err != io.EOF {
+		rest := text[end:]
+		end += int64(len(rest) - len(strings.TrimLeft(rest, " \t\r\n")))
+		return Value{}, LanErrs.JSONError{Token: token, Offset: end, Reason: "extra data after the JSON"}
+	}
+	return value, nil
+}
+
+type jsonParser struct {
+	decoder *json.Decoder
+	text    string
+	token   tokenizer.Token
+}
+
+// Turns an error from the decoder into a JSONError with the byte in the input where it went wrong
+func (p jsonParser) error(err error) error {
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return LanErrs.JSONError{Token: p.token, Offset: syntaxErr.Offset, Reason: syntaxErr.Error()}
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		return LanErrs.JSONError{Token: p.token, Offset: int64(len(p.text)), Reason: "unexpected end of JSON input"}
+	}
+	return LanErrs.JSONError{Token: p.token, Offset: p.decoder.InputOffset(), Reason: err.Error()}
+}
+
+func (p jsonParser) value() (Value, error) {
+	t, err := p.decoder.Token()
+	if err != nil {
+		return Value{}, p.error(err)
+	}
+
+	switch t := t.(type) {
+	case json.Delim:
+		if t == '[' {
+			return p.array()
+		}
+		return p.object()
+	case string:
+		return newString(t), nil
+	case bool:
+		return boolValue(t), nil
+	case json.Number:
+		return p.number(t)
+	}
+	return Value{ValueType: Nil}, nil
+}
+
+func (p jsonParser) array() (Value, error) {
+	items := []Value{}
+	for p.decoder.More() {
+		item, err := p.value()
+		if err != nil {
+			return Value{}, err
+		}
+		items = append(items, item)
+	}
+	if _, err := p.decoder.Token(); err != nil {
+		return Value{}, p.error(err)
+	}
+	return listValue(items), nil
+}
+
+func (p jsonParser) object() (Value, error) {
+	m := newOrderedMap()
+	for p.decoder.More() {
+		key, err := p.decoder.Token()
+		if err != nil {
+			return Value{}, p.error(err)
+		}
+		value, err := p.value()
+		if err != nil {
+			return Value{}, err
+		}
+		m.set(key.(string), value)
+	}
+	if _, err := p.decoder.Token(); err != nil {
+		return Value{}, p.error(err)
+	}
+	return mapValue(m), nil
+}
+
+func (p jsonParser) number(n json.Number) (Value, error) {
+	if !strings.ContainsAny(string(n), ".eE") {
+		i, _ := new(big.Int).SetString(string(n), 10)
+		return bigIntValue(i, p.token)
+	}
+	d, err := decimal.Parse(string(n))
+	if err != nil {
+		return Value{}, LanErrs.JSONError{Token: p.token, Offset: p.decoder.InputOffset() - int64(len(n)), Reason: err.Error()}
+	}
+	return decimalValue(d), nil
+}
+
+// The most spaces each level of stringify can be indented by
+const maxJSONIndent = 100
+
+// stringify(value) gives the JSON on one line, stringify(value, indent) puts each item on its own line
+// indented by that many spaces
+func jsonStringify(args []Value, token tokenizer.Token) (Value, error) {
+	indent := 0
+	if len(args) == 2 {
+		if args[1].ValueType != Integer || intUncast(args[1].Value) < 0 || intUncast(args[1].Value) > maxJSONIndent {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "json.stringify",
+				Expected: "an int indent from 0 to " + strconv.Itoa(maxJSONIndent), Got: describeValue(args[1])}
+		}
+		indent = intUncast(args[1].Value)
+	}
+
+	var out strings.Builder
+	if err := writeJSON(&out, args[0], indent, 0, token); err != nil {
+		return Value{}, err
+	}
+	return newString(out.String()), nil
+}
+
+func writeJSON(out *strings.Builder, v Value, indent int, depth int, token tokenizer.Token) error {
+	switch v.ValueType {
+	case Nil:
+		out.WriteString("null")
+	case Integer, BigInteger, Decimal, Bool:
+		out.WriteString(valueString(v))
+	case Float:
+		if !isFinite(v) {
+			return conversionError(v, "JSON", token)
+		}
+		out.WriteString(valueString(v))
+	case str:
+		out.WriteString(jsonString(valueString(v)))
+	case List:
+		items := lists[v.Value]
+		if len(items) == 0 {
+			out.WriteString("[]")
+			return nil
+		}
+		out.WriteString("[")
+		for i, item := range items {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			newJSONLine(out, indent, depth+1)
+			if err := writeJSON(out, item, indent, depth+1, token); err != nil {
+				return err
+			}
+		}
+		newJSONLine(out, indent, depth)
+		out.WriteString("]")
+	case Map:
+		m := maps[v.Value]
+		if len(m.keys) == 0 {
+			out.WriteString("{}")
+			return nil
+		}
+		out.WriteString("{")
+		for i, key := range m.keys {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			newJSONLine(out, indent, depth+1)
+			out.WriteString(jsonString(key))
+			out.WriteString(":")
+			if indent > 0 {
+				out.WriteString(" ")
+			}
+			if err := writeJSON(out, m.values[key], indent, depth+1, token); err != nil {
+				return err
+			}
+		}
+		newJSONLine(out, indent, depth)
+		out.WriteString("}")
+	default:
+		return conversionError(v, "JSON", token)
+	}
+	return nil
+}
+
+func newJSONLine(out *strings.Builder, indent int, depth int) {
+	if indent > 0 {
+		out.WriteString("\n" + strings.Repeat(" ", indent*depth))
+	}
+}
+
+// Quotes a string for JSON without escaping <, > and & as encoding/json does by default
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
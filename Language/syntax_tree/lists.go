@@ -0,0 +1,194 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+)
+
+// Lists are kept in this list and the Value holds their index, so every copy of a list Value shares its items
+var lists [][]Value
+
+func listValue(items []Value) Value {
+	lists = append(lists, items)
+	return Value{ValueType: List, Value: uint64(len(lists) - 1)}
+}
+
+// A list written with square brackets, e.g. [1, 2, 3]
+type ListNode struct {
+	Token tokenizer.Token
+	Items []Node
+}
+
+func (node ListNode) Evaluate() (Value, error) {
+	items := make([]Value, len(node.Items))
+	for i, item := range node.Items {
+		value, err := item.Evaluate()
+		if err != nil {
+			return Value{}, err
+		}
+		items[i] = value
+	}
+	return listValue(items), nil
+}
+
+// Gets one character of a string or one item of a list, e.g. name[0]. Negative indexes count from the end.
+// Maps are indexed with their keys, e.g. config["name"], and a key which isn't in the map is an error
+type IndexNode struct {
+	Token  tokenizer.Token
+	Target Node
+	Index  Node
+}
+
+func (node IndexNode) Evaluate() (Value, error) {
+	target, err := node.Target.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	index, err := node.Index.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+
+	if target.ValueType == Map {
+		if index.ValueType != str {
+			return Value{}, LanErrs.WrongArgumentError{Token: node.Token, Function: "[]", Expected: "a string key", Got: kindName(index.ValueType)}
+		}
+		value, ok := maps[target.Value].values[Global.Strings[index.Value]]
+		if !ok {
+			return Value{}, LanErrs.NoKeyError{Token: node.Token, Key: Global.Strings[index.Value]}
+		}
+		return value, nil
+	}
+
+	length, err := sequenceLength(target, node.Token)
+	if err != nil {
+		return Value{}, err
+	}
+	if index.ValueType != Integer {
+		return Value{}, LanErrs.WrongArgumentError{Token: node.Token, Function: "[]", Expected: "an int index", Got: kindName(index.ValueType)}
+	}
+	i := intUncast(index.Value)
+	if i < 0 {
+		i += length
+	}
+	if i < 0 || i >= length {
+		return Value{}, LanErrs.IndexOutOfRangeError{Token: node.Token, Index: strconv.Itoa(intUncast(index.Value)), Length: length}
+	}
+
+	if target.ValueType == List {
+		return lists[target.Value][i], nil
+	}
+	return newString(string([]rune(Global.Strings[target.Value])[i])), nil
+}
+
+// Gets part of a string or list, e.g. name[1:3]. A missing start or end means the start or end of the
+// string, and indexes past either end are moved back to it
+type SliceNode struct {
+	Token  tokenizer.Token
+	Target Node
+	Start  Node
+	End    Node
+}
+
+func (node SliceNode) Evaluate() (Value, error) {
+	target, err := node.Target.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	length, err := sequenceLength(target, node.Token)
+	if err != nil {
+		return Value{}, err
+	}
+	start, err := sliceBound(node.Start, 0, length, node.Token)
+	if err != nil {
+		return Value{}, err
+	}
+	end, err := sliceBound(node.End, length, length, node.Token)
+	if err != nil {
+		return Value{}, err
+	}
+	if end < start {
+		end = start
+	}
+
+	if target.ValueType == List {
+		items := make([]Value, end-start)
+		copy(items, lists[target.Value][start:end])
+		return listValue(items), nil
+	}
+	return newString(string([]rune(Global.Strings[target.Value])[start:end])), nil
+}
+
+func sliceBound(node Node, missing int, length int, token tokenizer.Token) (int, error) {
+	bound, err := node.Evaluate()
+	if err != nil {
+		return 0, err
+	}
+	switch bound.ValueType {
+	case Nil:
+		return missing, nil
+	case Integer:
+	default:
+		return 0, LanErrs.WrongArgumentError{Token: token, Function: "[:]", Expected: "an int index", Got: kindName(bound.ValueType)}
+	}
+
+	i := intUncast(bound.Value)
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0, nil
+	}
+	if i > length {
+		return length, nil
+	}
+	return i, nil
+}
+
+// The number of code points in a string or items in a list
+func sequenceLength(v Value, token tokenizer.Token) (int, error) {
+	switch v.ValueType {
+	case str:
+		return len([]rune(Global.Strings[v.Value])), nil
+	case List:
+		return len(lists[v.Value]), nil
+	}
+	return 0, LanErrs.WrongArgumentError{Token: token, Function: "[]", Expected: "a string or list", Got: kindName(v.ValueType)}
+}
+
+// Checks if two values are the same. Lists are the same when all of their items are
+func valuesEqual(left Value, right Value) bool {
+	switch {
+	case isNum(left.ValueType) && isNum(right.ValueType):
+		return compareNums(left, right) == 0
+	case left.ValueType != right.ValueType:
+		return false
+	}
+
+	switch left.ValueType {
+	case str:
+		return Global.Strings[left.Value] == Global.Strings[right.Value]
+	case List:
+		l, r := lists[left.Value], lists[right.Value]
+		if len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !valuesEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	case Map:
+		return mapsEqual(maps[left.Value], maps[right.Value])
+	case Record:
+		return recordsEqual(records[left.Value], records[right.Value])
+	case Time:
+		return times[left.Value].Equal(times[right.Value])
+	case Nil:
+		return true
+	}
+	return left.Value == right.Value
+}
@@ -0,0 +1,67 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+	"strings"
+)
+
+// Maps are kept in this list and the Value holds their index, in the same way as lists. Keys are strings and
+// are kept in the order they were added
+var maps []*orderedMap
+
+type orderedMap struct {
+	keys   []string
+	values map[string]Value
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]Value)}
+}
+
+func mapValue(m *orderedMap) Value {
+	maps = append(maps, m)
+	return Value{ValueType: Map, Value: uint64(len(maps) - 1)}
+}
+
+func (m *orderedMap) set(key string, value Value) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) String() string {
+	items := make([]string, len(m.keys))
+	for i, key := range m.keys {
+		items[i] = strconv.Quote(key) + ": " + describeValue(m.values[key])
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+func mapsEqual(l *orderedMap, r *orderedMap) bool {
+	if len(l.keys) != len(r.keys) {
+		return false
+	}
+	for key, value := range l.values {
+		other, ok := r.values[key]
+		if !ok || !valuesEqual(value, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// A list of the keys of a map in the order they were added
+func keys(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != Map {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "keys", Expected: "a map", Got: kindName(args[0].ValueType)}
+	}
+	m := maps[args[0].Value]
+	items := make([]Value, len(m.keys))
+	for i, key := range m.keys {
+		items[i] = newString(key)
+	}
+	return listValue(items), nil
+}
@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strings"
+)
+
+// Runs the first case whose pattern matches the value and whose guard, if it has one, is true. Nothing is run
+// when no case matches
+type MatchNode struct {
+	Token      tokenizer.Token
+	Expression Node
+	Cases      []Node
+}
+
+func (node MatchNode) Evaluate() (Value, error) {
+	value, err := node.Expression.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+
+	for _, statement := range node.Cases {
+		arm, ok := statement.(CaseNode)
+		if !ok {
+			return Value{}, LanErrs.MatchSyntaxError{Token: node.Token, Reason: "each line must be a pattern then => and what to run"}
+		}
+		matched, err := matchPattern(arm.Pattern, value)
+		if err != nil {
+			return Value{}, err
+		}
+		if matched && arm.Guard != nil {
+			guard, err := arm.Guard.Evaluate()
+			if err != nil {
+				return Value{}, err
+			}
+			if guard.ValueType != Bool {
+				return Value{}, LanErrs.ExpectedBoolWithControlError{Token: arm.Token}
+			}
+			matched = guard.Value == 1
+		}
+		if matched {
+			return Value{}, evaluateBlock(arm.Statements)
+		}
+	}
+	return Value{}, nil
+}
+
+// One arm of a match, pattern => body or pattern if guard => body
+type CaseNode struct {
+	Token      tokenizer.Token
+	Pattern    Node
+	Guard      Node
+	Statements []Node
+}
+
+func (node CaseNode) Evaluate() (Value, error) {
+	return Value{}, LanErrs.MatchSyntaxError{Token: node.Token, Reason: "=> can only be used inside match"}
+}
+
+// The if of a guard, the parser splits it into the pattern and the guard of a case
+type GuardNode struct {
+	Token     tokenizer.Token
+	Pattern   Node
+	Condition Node
+}
+
+func (node GuardNode) Evaluate() (Value, error) {
+	return Value{}, LanErrs.MatchSyntaxError{Token: node.Token, Reason: "a guard can only be used in a pattern"}
+}
+
+// Matches numbers, or strings, from Left to Right including both ends, e.g. 1..5
+type RangeNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node RangeNode) Evaluate() (Value, error) {
+	return Value{}, LanErrs.MatchSyntaxError{Token: node.Token, Reason: ".. can only be used in a pattern"}
+}
+
+// Checks a value against a pattern. _ matches anything, "text" + _ matches strings starting with text, patterns
+// joined by | match when any of them do and anything else is a value the value must be equal to
+func matchPattern(pattern Node, value Value) (bool, error) {
+	switch pattern := pattern.(type) {
+	case IdentifierNode:
+		if isWildcard(pattern) {
+			return true, nil
+		}
+
+	case OrNode:
+		matched, err := matchPattern(pattern.Left, value)
+		if err != nil || matched {
+			return matched, err
+		}
+		return matchPattern(pattern.Right, value)
+
+	case RangeNode:
+		return matchRange(pattern, value)
+
+	case AddNode:
+		if right, ok := pattern.Right.(IdentifierNode); ok && isWildcard(right) {
+			prefix, err := pattern.Left.Evaluate()
+			if err != nil {
+				return false, err
+			}
+			if prefix.ValueType != str {
+				return false, LanErrs.MatchSyntaxError{Token: pattern.Token, Reason: "only a string can come before + _"}
+			}
+			return value.ValueType == str && strings.HasPrefix(Global.Strings[value.Value], Global.Strings[prefix.Value]), nil
+		}
+	}
+
+	expected, err := pattern.Evaluate()
+	if err != nil {
+		return false, err
+	}
+	return valuesEqual(value, expected), nil
+}
+
+func isWildcard(node IdentifierNode) bool {
+	return identifierName(node) == "_"
+}
+
+// A value which can't be compared with the ends of the range doesn't match it
+func matchRange(pattern RangeNode, value Value) (bool, error) {
+	low, err := pattern.Left.Evaluate()
+	if err != nil {
+		return false, err
+	}
+	high, err := pattern.Right.Evaluate()
+	if err != nil {
+		return false, err
+	}
+	if _, err := compareValues(low, high, pattern.Token); err != nil {
+		return false, err
+	}
+
+	fromLow, err := compareValues(value, low, pattern.Token)
+	if err != nil {
+		return false, nil
+	}
+	toHigh, _ := compareValues(value, high, pattern.Token)
+	return fromLow >= 0 && toHigh <= 0, nil
+}
@@ -0,0 +1,249 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// The math module. Functions keep to the same number rules as the operators: ints stay ints where the answer is
+// a whole number, decimals stay decimals and floats stay floats. Answers which can't be exact, such as sqrt(2)
+// with an int, are given as decimals
+func newMathModule() Value {
+	members := map[string]Value{
+		"pi": decimalConstant("3.141592653589793238462643383"),
+		"e":  decimalConstant("2.718281828459045235360287471"),
+	}
+	for _, f := range []function{
+		{name: "abs", minArgs: 1, maxArgs: 1, call: mathAbs},
+		{name: "min", minArgs: 1, maxArgs: -1, call: mathMin},
+		{name: "max", minArgs: 1, maxArgs: -1, call: mathMax},
+		{name: "floor", minArgs: 1, maxArgs: 1, call: mathFloor},
+		{name: "ceil", minArgs: 1, maxArgs: 1, call: mathCeil},
+		{name: "round", minArgs: 1, maxArgs: 2, call: mathRound},
+		{name: "sqrt", minArgs: 1, maxArgs: 1, call: mathSqrt},
+		{name: "log", minArgs: 1, maxArgs: 2, call: mathLog},
+		{name: "exp", minArgs: 1, maxArgs: 1, call: floatFunction("exp", math.Exp, nil)},
+		{name: "sin", minArgs: 1, maxArgs: 1, call: floatFunction("sin", math.Sin, nil)},
+		{name: "cos", minArgs: 1, maxArgs: 1, call: floatFunction("cos", math.Cos, nil)},
+		{name: "tan", minArgs: 1, maxArgs: 1, call: floatFunction("tan", math.Tan, nil)},
+		{name: "asin", minArgs: 1, maxArgs: 1, call: floatFunction("asin", math.Asin, isUnitRange)},
+		{name: "acos", minArgs: 1, maxArgs: 1, call: floatFunction("acos", math.Acos, isUnitRange)},
+		{name: "atan", minArgs: 1, maxArgs: 1, call: floatFunction("atan", math.Atan, nil)},
+	} {
+		members[f.name] = functionValue(function{name: "math." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, call: f.call})
+	}
+	return moduleValue("math", members)
+}
+
+func decimalConstant(text string) Value {
+	d, _ := decimal.Parse(text)
+	return decimalValue(d)
+}
+
+// Gets a number argument given to a builtin
+func numberArg(args []Value, i int, function string, token tokenizer.Token) (Value, error) {
+	if !isNum(args[i].ValueType) {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a number", Got: kindName(args[i].ValueType)}
+	}
+	return args[i], nil
+}
+
+func domainError(function string, v Value, token tokenizer.Token) error {
+	return LanErrs.DomainError{Token: token, Function: "math." + function, Value: describeValue(v)}
+}
+
+// Floats which are infinite or not a number can't be turned into ints or decimals
+func isFinite(v Value) bool {
+	if v.ValueType != Float {
+		return true
+	}
+	f := FloatUncast(v.Value)
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func mathAbs(args []Value, token tokenizer.Token) (Value, error) {
+	x, err := numberArg(args, 0, "math.abs", token)
+	if err != nil {
+		return Value{}, err
+	}
+	switch x.ValueType {
+	case Integer, BigInteger:
+		return bigIntValue(new(big.Int).Abs(asBigInt(x)), token)
+	case Float:
+		return FloatValue(math.Abs(FloatUncast(x.Value))), nil
+	}
+	return decimalValue(asDecimal(x).Abs()), nil
+}
+
+func mathMin(args []Value, token tokenizer.Token) (Value, error) {
+	return pickNumber(args, "math.min", -1, token)
+}
+
+func mathMax(args []Value, token tokenizer.Token) (Value, error) {
+	return pickNumber(args, "math.max", 1, token)
+}
+
+// Gives the smallest (order -1) or biggest (order 1) of the numbers, which can be given one by one or as a list
+func pickNumber(args []Value, function string, order int, token tokenizer.Token) (Value, error) {
+	numbers := args
+	if len(args) == 1 && args[0].ValueType == List {
+		numbers = lists[args[0].Value]
+		if len(numbers) == 0 {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a list which isn't empty", Got: "[]"}
+		}
+	}
+
+	var picked Value
+	for i := range numbers {
+		number, err := numberArg(numbers, i, function, token)
+		if err != nil {
+			return Value{}, err
+		}
+		if i == 0 || compareNums(number, picked) == order {
+			picked = number
+		}
+	}
+	return picked, nil
+}
+
+func mathFloor(args []Value, token tokenizer.Token) (Value, error) {
+	return roundToInt(args, "floor", decimal.Floor, token)
+}
+
+func mathCeil(args []Value, token tokenizer.Token) (Value, error) {
+	return roundToInt(args, "ceil", decimal.Ceiling, token)
+}
+
+// Rounds a number to a whole number which is given as an int
+func roundToInt(args []Value, function string, mode decimal.RoundingMode, token tokenizer.Token) (Value, error) {
+	x, err := numberArg(args, 0, "math."+function, token)
+	if err != nil {
+		return Value{}, err
+	}
+	if isInt(x.ValueType) {
+		return x, nil
+	}
+	if !isFinite(x) {
+		return Value{}, domainError(function, x, token)
+	}
+	return bigIntValue(asDecimal(x).Round(0, mode).BigInt(), token)
+}
+
+// round(x) gives the nearest int. round(x, digits) keeps digits after the decimal point and gives the same
+// kind of number as x, a negative number of digits rounds to tens, hundreds and so on.
+// Halves are rounded in the way set by the interpreter.WithRounding option
+func mathRound(args []Value, token tokenizer.Token) (Value, error) {
+	if len(args) == 1 {
+		return roundToInt(args, "round", settings.Rounding, token)
+	}
+
+	x, err := numberArg(args, 0, "math.round", token)
+	if err != nil {
+		return Value{}, err
+	}
+	digits := intUncast(args[1].Value)
+	if args[1].ValueType != Integer || digits > decimal.MaxPowerDigits || digits < -decimal.MaxPowerDigits {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "math.round",
+			Expected: "an int number of digits from -" + strconv.Itoa(decimal.MaxPowerDigits) + " to " + strconv.Itoa(decimal.MaxPowerDigits),
+			Got: describeValue(args[1])}
+	}
+	if !isFinite(x) {
+		return Value{}, domainError("round", x, token)
+	}
+
+	rounded := asDecimal(x).Round(digits, settings.Rounding)
+	switch x.ValueType {
+	case Integer, BigInteger:
+		return bigIntValue(rounded.BigInt(), token)
+	case Float:
+		return FloatValue(rounded.Float64()), nil
+	}
+	return decimalValue(rounded), nil
+}
+
+// Square roots of ints and decimals are worked out to the decimal precision rather than through a float
+func mathSqrt(args []Value, token tokenizer.Token) (Value, error) {
+	x, err := numberArg(args, 0, "math.sqrt", token)
+	if err != nil {
+		return Value{}, err
+	}
+	if x.ValueType == Float {
+		return floatFunction("sqrt", math.Sqrt, func(f float64) bool { return f >= 0 })(args, token)
+	}
+
+	d := asDecimal(x)
+	if d.Sign() < 0 {
+		return Value{}, domainError("sqrt", x, token)
+	}
+	digits := settings.DecimalPrecision + 5
+	f, _, err := big.ParseFloat(d.String(), 10, uint(digits*4), big.ToNearestEven)
+	if err != nil {
+		return Value{}, domainError("sqrt", x, token)
+	}
+	root, err := decimal.Parse(new(big.Float).SetPrec(f.Prec()).Sqrt(f).Text('e', digits))
+	if err != nil {
+		return Value{}, domainError("sqrt", x, token)
+	}
+	return decimalValue(root.RoundSignificant(settings.DecimalPrecision, settings.Rounding)), nil
+}
+
+// log(x) is the natural log, log(x, base) uses the given base
+func mathLog(args []Value, token tokenizer.Token) (Value, error) {
+	if len(args) == 1 {
+		return floatFunction("log", math.Log, isPositive)(args, token)
+	}
+
+	base, err := numberArg(args, 1, "math.log", token)
+	if err != nil {
+		return Value{}, err
+	}
+	b := asFloat(base)
+	if b <= 0 || b == 1 {
+		return Value{}, domainError("log", base, token)
+	}
+
+	logOf := func(f float64) float64 { return math.Log(f) / math.Log(b) }
+	switch b {
+	case 10:
+		logOf = math.Log10
+	case 2:
+		logOf = math.Log2
+	}
+	return floatFunction("log", logOf, isPositive)(args[:1], token)
+}
+
+func isPositive(f float64) bool {
+	return f > 0
+}
+
+func isUnitRange(f float64) bool {
+	return f >= -1 && f <= 1
+}
+
+// Makes a builtin from a float function. inDomain checks the number can be used, it can be nil when any number can.
+// Ints and decimals give a decimal which is only as exact as a float
+func floatFunction(name string, f func(float64) float64, inDomain func(float64) bool) func([]Value, tokenizer.Token) (Value, error) {
+	return func(args []Value, token tokenizer.Token) (Value, error) {
+		x, err := numberArg(args, 0, "math."+name, token)
+		if err != nil {
+			return Value{}, err
+		}
+		if inDomain != nil && !inDomain(asFloat(x)) {
+			return Value{}, domainError(name, x, token)
+		}
+
+		answer := f(asFloat(x))
+		if x.ValueType == Float {
+			return FloatValue(answer), nil
+		}
+		d, err := decimal.FromFloat(answer)
+		if err != nil {
+			return Value{}, domainError(name, x, token)
+		}
+		return decimalValue(d), nil
+	}
+}
@@ -0,0 +1,82 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+)
+
+// Modules are kept in this list and the Value holds their index. A module is a set of names used with a .
+// in front, e.g. math.sqrt
+var modules []module
+
+type module struct {
+	name    string
+	members map[string]Value
+}
+
+func moduleValue(name string, members map[string]Value) Value {
+	modules = append(modules, module{name: name, members: members})
+	return Value{ValueType: Module, Value: uint64(len(modules) - 1)}
+}
+
+// Gets a name from a module, e.g. math.pi, a field of a caught error, e.g. err.message, or a field or method
+// of a record, e.g. person.name
+type MemberNode struct {
+	Token  tokenizer.Token
+	Target Node
+	Name   Node
+}
+
+func (node MemberNode) Evaluate() (Value, error) {
+	identifier, ok := node.Name.(IdentifierNode)
+	if !ok {
+		return Value{}, LanErrs.ExpectedMemberNameError{Token: node.Token}
+	}
+	target, err := node.Target.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	if target.ValueType == Error {
+		member, ok := caughtErrors[target.Value].member(identifierName(identifier))
+		if !ok {
+			return Value{}, LanErrs.NoMemberError{Token: identifier.Token, Module: "error", Member: identifierName(identifier)}
+		}
+		return member, nil
+	}
+	if target.ValueType == Record {
+		r := records[target.Value]
+		member, ok := r.member(identifierName(identifier), target)
+		if !ok {
+			return Value{}, LanErrs.NoMemberError{Token: identifier.Token, Module: r.typ.name, Member: identifierName(identifier)}
+		}
+		return member, nil
+	}
+	if target.ValueType != Module {
+		return Value{}, LanErrs.WrongArgumentError{Token: node.Token, Function: ".", Expected: "a module", Got: kindName(target.ValueType)}
+	}
+
+	m := modules[target.Value]
+	name := identifierName(identifier)
+	member, ok := m.members[name]
+	if !ok {
+		return Value{}, LanErrs.NoMemberError{Token: identifier.Token, Module: m.name, Member: name}
+	}
+	return member, nil
+}
+
+// Gives a field of a record a new value, e.g. person.age = 31
+func (node MemberNode) assign(value Value, token tokenizer.Token) error {
+	identifier, ok := node.Name.(IdentifierNode)
+	if !ok {
+		return LanErrs.ExpectedMemberNameError{Token: node.Token}
+	}
+	target, err := node.Target.Evaluate()
+	if err != nil {
+		return err
+	}
+	if target.ValueType != Record {
+		return LanErrs.WrongArgumentError{Token: node.Token, Function: ".", Expected: "a record to give a field a new value",
+			Got: kindName(target.ValueType)}
+	}
+	return records[target.Value].setField(identifierName(identifier), value, token, identifier.Token)
+}
@@ -1,13 +1,15 @@
 package tree
 
 import (
-	"errors"
 	"fmt"
+	"io"
 	"language/Global"
 	"language/LanErrs"
+	"language/decimal"
 	"language/tokenizer"
-	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -24,7 +26,18 @@ const (
 	Decimal
 	str
 	Bool
-	Identifier
+	BigInteger
+	Float
+	Nil
+	Function
+	List
+	Module
+	Map
+	Time
+	Duration
+	Error
+	Record
+	Enum
 )
 
 type Value struct {
@@ -39,10 +52,10 @@ func intValue(val int) Value {
 	return Value{ValueType: Integer, Value: v}
 }
 
-func DecimalValue(val float64) Value {
+func FloatValue(val float64) Value {
 	p := unsafe.Pointer(&val)
 	f := *(*uint64)(p)
-	return Value{ValueType: Decimal, Value: f}
+	return Value{ValueType: Float, Value: f}
 }
 
 func stringValue(val string) (Value, error) {
@@ -60,7 +73,7 @@ func intUncast(val uint64) int {
 	return v
 }
 
-func DecimalUncast(val uint64) float64 {
+func FloatUncast(val uint64) float64 {
 	p := unsafe.Pointer(&val)
 	v := *(*float64)(p)
 	return v
@@ -84,11 +97,11 @@ type IntNode struct {
 }
 
 func (node IntNode) Evaluate() (Value, error) {
-	number, err := strconv.Atoi(node.Token.Text)
-	if err != nil {
-		return Value{}, err
+	number, ok := new(big.Int).SetString(node.Token.Text, 10)
+	if !ok {
+		return Value{}, LanErrs.InvalidNumberError{Token: node.Token, Type: "int"}
 	}
-	return intValue(number), nil
+	return bigIntValue(number, node.Token)
 }
 
 type DecimalNode struct {
@@ -96,11 +109,11 @@ type DecimalNode struct {
 }
 
 func (node DecimalNode) Evaluate() (Value, error) {
-	number, err := strconv.ParseFloat(node.Token.Text, 64)
+	number, err := decimal.Parse(node.Token.Text)
 	if err != nil {
-		return Value{}, err
+		return Value{}, LanErrs.InvalidNumberError{Token: node.Token, Type: "decimal"}
 	}
-	return DecimalValue(number), nil
+	return decimalValue(number), nil
 }
 
 type StringNode struct {
@@ -132,37 +145,15 @@ func (node MultiplyNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if isTime(left.ValueType) || isTime(right.ValueType) {
+		return multiplyTimes(left, right, node.Token)
 	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			return intValue(intUncast(left.Value) * intUncast(right.Value)), nil
-		}
-		return DecimalValue(float64(intUncast(left.Value)) * DecimalUncast(right.Value)), nil
-	case Decimal:
-		if right.ValueType == Decimal {
-			return DecimalValue(DecimalUncast(left.Value) * DecimalUncast(right.Value)), nil
-		}
-		return DecimalValue(DecimalUncast(left.Value) * float64(intUncast(right.Value))), nil
+	if isNum(left.ValueType) && isNum(right.ValueType) {
+		return multiplyNums(left, right, node.Token)
 	}
 
 	//Error
@@ -196,37 +187,17 @@ func (node AddNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if isTime(left.ValueType) || isTime(right.ValueType) {
+		return addTimes(left, right, node.Token)
 	}
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			return intValue(intUncast(left.Value) + intUncast(right.Value)), nil
-		}
-		return DecimalValue(float64(intUncast(left.Value)) + DecimalUncast(right.Value)), nil
-	case Decimal:
-		if right.ValueType == Decimal {
-			return DecimalValue(DecimalUncast(left.Value) + DecimalUncast(right.Value)), nil
-		}
-		return DecimalValue(DecimalUncast(left.Value) + float64(intUncast(right.Value))), nil
-	case str:
+	if isNum(left.ValueType) && isNum(right.ValueType) {
+		return addNums(left, right, node.Token)
+	}
+	if left.ValueType == str {
 		val, err := addStrings(Global.Strings[left.Value], Global.Strings[right.Value])
 		if err != nil {
 			return Value{}, err
@@ -244,6 +215,7 @@ type DivideNode struct {
 	Right Node
 }
 
+//Dividing with / always gives a decimal, so 7 / 2 is 3.5. Use div to get a whole number
 func (node DivideNode) Evaluate() (Value, error) {
 	left, err := node.Left.Evaluate()
 	if err != nil {
@@ -254,50 +226,54 @@ func (node DivideNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if isTime(left.ValueType) || isTime(right.ValueType) {
+		return divideTimes(left, right, node.Token)
+	}
+	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
+		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
+	}
+	if !isNum(left.ValueType) {
+		return Value{}, LanErrs.WrongTypeUsedWithBinOpError{node.Token}
 	}
 
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
+	return divideNums(left, right, node.Token)
+}
+
+//Whole number division which drops anything after the decimal point, so 7 div 2 is 3 and -7 div 2 is -3
+type IntDivideNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node IntDivideNode) Evaluate() (Value, error) {
+	left, err := node.Left.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	right, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
 	}
 
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
-		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
+		return Value{}, LanErrs.IncompatibleTypeError{Token: node.Token}
 	}
-
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			return intValue(intUncast(left.Value) / intUncast(right.Value)), nil
-		}
-		return DecimalValue(float64(intUncast(left.Value)) / DecimalUncast(right.Value)), nil
-	case Decimal:
-		if right.ValueType == Decimal {
-			return DecimalValue(DecimalUncast(left.Value) / DecimalUncast(right.Value)), nil
-		}
-		return DecimalValue(DecimalUncast(left.Value) / float64(intUncast(right.Value))), nil
+	if !isNum(left.ValueType) {
+		return Value{}, LanErrs.WrongTypeUsedWithBinOpError{Token: node.Token}
 	}
 
-	//return error
-	return Value{}, LanErrs.WrongTypeUsedWithBinOpError{node.Token}
+	return intDivideNums(left, right, node.Token)
 }
 
-type SubtractNode struct {
+//The remainder left over from div, it has the same sign as the left side so -7 % 2 is -1
+type ModuloNode struct {
 	Token tokenizer.Token
 	Left  Node
 	Right Node
 }
 
-func (node SubtractNode) Evaluate() (Value, error) {
+func (node ModuloNode) Evaluate() (Value, error) {
 	left, err := node.Left.Evaluate()
 	if err != nil {
 		return Value{}, err
@@ -307,37 +283,41 @@ func (node SubtractNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
+		return Value{}, LanErrs.IncompatibleTypeError{Token: node.Token}
+	}
+	if !isNum(left.ValueType) {
+		return Value{}, LanErrs.WrongTypeUsedWithBinOpError{Token: node.Token}
 	}
 
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
+	return moduloNums(left, right, node.Token)
+}
+
+type SubtractNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node SubtractNode) Evaluate() (Value, error) {
+	left, err := node.Left.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	right, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
 	}
 
+	if isTime(left.ValueType) || isTime(right.ValueType) {
+		return subtractTimes(left, right, node.Token)
+	}
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			return intValue(intUncast(left.Value) - intUncast(right.Value)), nil
-		}
-		return DecimalValue(float64(intUncast(left.Value)) - DecimalUncast(right.Value)), nil
-	case Decimal:
-		if right.ValueType == Decimal {
-			return DecimalValue(DecimalUncast(left.Value) - DecimalUncast(right.Value)), nil
-		}
-		return DecimalValue(DecimalUncast(left.Value) - float64(intUncast(right.Value))), nil
+	if isNum(left.ValueType) && isNum(right.ValueType) {
+		return subtractNums(left, right, node.Token)
 	}
 	//return error
 	return Value{}, LanErrs.WrongTypeUsedWithBinOpError{node.Token}
@@ -360,37 +340,12 @@ func (node ExpoNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			return intValue(int(math.Pow(float64(intUncast(left.Value)), float64(intUncast(right.Value))))), nil
-		}
-		return DecimalValue(math.Pow(float64(intUncast(left.Value)), DecimalUncast(right.Value))), nil
-	case Decimal:
-		if right.ValueType == Decimal {
-			return DecimalValue(math.Pow(DecimalUncast(left.Value), DecimalUncast(right.Value))), nil
-		}
-		return DecimalValue(math.Pow(DecimalUncast(left.Value), float64(intUncast(right.Value)))), nil
+	if isNum(left.ValueType) && isNum(right.ValueType) {
+		return powerNums(left, right, node.Token)
 	}
 
 	//Return error because node types cannot be used with exspo
@@ -409,35 +364,23 @@ func (node OrNode) Evaluate() (Value, error) {
 	if err != nil {
 		return Value{}, err
 	}
-	right, err := node.Right.Evaluate()
-	if err != nil {
-		return Value{}, err
-	}
-
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if left.ValueType != Bool {
+		return Value{}, LanErrs.ExpectedBoolError{Token: node.Token}
 	}
 
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
+	//The right side is only run when it can change the result
+	if left.Value == 1 {
+		return left, nil
 	}
 
-	if left.ValueType != Bool || right.ValueType != Bool {
-		return Value{}, LanErrs.ExpectedBoolError{node.Token}
+	right, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
 	}
-
-	if left.Value == 1 || right.Value == 1 {
-		return Value{Bool, 1}, nil
+	if right.ValueType != Bool {
+		return Value{}, LanErrs.ExpectedBoolError{Token: node.Token}
 	}
-	return Value{Bool, 0}, nil
+	return right, nil
 }
 
 type AndNode struct {
@@ -451,35 +394,23 @@ func (node AndNode) Evaluate() (Value, error) {
 	if err != nil {
 		return Value{}, err
 	}
-	right, err := node.Right.Evaluate()
-	if err != nil {
-		return Value{}, err
-	}
-
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
+	if left.ValueType != Bool {
+		return Value{}, LanErrs.ExpectedBoolError{Token: node.Token}
 	}
 
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
+	//The right side is only run when it can change the result
+	if left.Value == 0 {
+		return left, nil
 	}
 
-	if left.ValueType != Bool || right.ValueType != Bool {
-		return Value{}, LanErrs.ExpectedBoolError{node.Token}
+	right, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
 	}
-
-	if left.Value == 1 && right.Value == 1 {
-		return Value{Bool, 1}, nil
+	if right.ValueType != Bool {
+		return Value{}, LanErrs.ExpectedBoolError{Token: node.Token}
 	}
-	return Value{Bool, 0}, nil
+	return right, nil
 }
 
 type DoesEqualNode struct {
@@ -498,49 +429,26 @@ func (node DoesEqualNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
+	//nil is only equal to nil, it can be compared with any kind so a variable can be checked for nil
+	if left.ValueType == Nil || right.ValueType == Nil {
+		if left.ValueType == right.ValueType {
+			return Value{Bool, 1}, nil
 		}
-		left = val
+		return Value{Bool, 0}, nil
 	}
 
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) == intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) == DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) == DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) == float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
+	if isNum(left.ValueType) {
+		if compareNums(left, right) == 0 {
+			return Value{Bool, 1}, nil
 		}
+		return Value{Bool, 0}, nil
+	}
 
+	switch left.ValueType {
 	case str:
 		l := Global.Strings[left.Value]
 		r := Global.Strings[right.Value]
@@ -548,10 +456,15 @@ func (node DoesEqualNode) Evaluate() (Value, error) {
 			return Value{Bool, 1}, nil
 		}
 
-	case Bool:
+	case Bool, Function, Error, Enum:
 		if left.Value == right.Value {
 			return Value{Bool, 1}, nil
 		}
+
+	case List, Map, Time, Duration, Record:
+		if valuesEqual(left, right) {
+			return Value{Bool, 1}, nil
+		}
 	}
 
 	return Value{Bool, 0}, nil
@@ -573,49 +486,25 @@ func (node NotEqualNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
+	if left.ValueType == Nil || right.ValueType == Nil {
+		if left.ValueType != right.ValueType {
+			return Value{Bool, 1}, nil
 		}
-		left = val
+		return Value{Bool, 0}, nil
 	}
 
 	if left.ValueType != right.ValueType && !(isNum(left.ValueType) && isNum(right.ValueType)) {
 		return Value{}, LanErrs.IncompatibleTypeError{node.Token}
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) != intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) != DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) != DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) != float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
+	if isNum(left.ValueType) {
+		if compareNums(left, right) != 0 {
+			return Value{Bool, 1}, nil
 		}
+		return Value{Bool, 0}, nil
+	}
 
+	switch left.ValueType {
 	case str:
 		l := Global.Strings[left.Value]
 		r := Global.Strings[right.Value]
@@ -623,16 +512,35 @@ func (node NotEqualNode) Evaluate() (Value, error) {
 			return Value{Bool, 1}, nil
 		}
 
-	case Bool:
+	case Bool, Function, Error, Enum:
 		if left.Value != right.Value {
 			return Value{Bool, 1}, nil
 		}
+
+	case List, Map, Time, Duration, Record:
+		if !valuesEqual(left, right) {
+			return Value{Bool, 1}, nil
+		}
 	}
 	return Value{Bool, 0}, nil
 }
 
+//Gives -1 when left comes first, 0 when they are the same and 1 when left comes after. Strings are put in
+//order by their characters' code points
+func compareValues(left Value, right Value, token tokenizer.Token) (int, error) {
+	switch {
+	case isNum(left.ValueType) && isNum(right.ValueType):
+		return compareNums(left, right), nil
+	case left.ValueType == str && right.ValueType == str:
+		return strings.Compare(Global.Strings[left.Value], Global.Strings[right.Value]), nil
+	case isTime(left.ValueType) && left.ValueType == right.ValueType:
+		return compareTimes(left, right), nil
+	}
+	return 0, LanErrs.MustBeNumWithComparisonOp{Token: token}
+}
+
 func isNum(v valueKind) bool {
-	if v == Integer || v == Decimal {
+	if v == Integer || v == BigInteger || v == Decimal || v == Float {
 		return true
 	}
 	return false
@@ -654,50 +562,14 @@ func (node BigThanEqualNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
-	if !isNum(left.ValueType) || !isNum(right.ValueType) {
-		return Value{}, LanErrs.MustBeNumWithComparisonOp{node.Token}
+	order, err := compareValues(left, right, node.Token)
+	if err != nil {
+		return Value{}, err
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) >= intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) >= DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) >= DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) >= float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
-		}
+	if order >= 0 {
+		return Value{Bool, 1}, nil
 	}
-
 	return Value{Bool, 0}, nil
 }
 
@@ -717,48 +589,13 @@ func (node BigThanNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
-	if !isNum(left.ValueType) || !isNum(right.ValueType) {
-		return Value{}, LanErrs.MustBeNumWithComparisonOp{node.Token}
+	order, err := compareValues(left, right, node.Token)
+	if err != nil {
+		return Value{}, err
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) > intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) > DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) > DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) > float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
-		}
+	if order > 0 {
+		return Value{Bool, 1}, nil
 	}
 	return Value{Bool, 0}, nil
 }
@@ -779,48 +616,13 @@ func (node SmallThanEqualNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
-	if !isNum(left.ValueType) || !isNum(right.ValueType) {
-		return Value{}, LanErrs.MustBeNumWithComparisonOp{node.Token}
+	order, err := compareValues(left, right, node.Token)
+	if err != nil {
+		return Value{}, err
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) <= intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) <= DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) <= DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) <= float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
-		}
+	if order <= 0 {
+		return Value{Bool, 1}, nil
 	}
 	return Value{Bool, 0}, nil
 }
@@ -841,48 +643,13 @@ func (node SmallThanNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
-	if left.ValueType == Identifier {
-		val, err := fetchVar(left.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		left = val
-	}
-
-	if !isNum(left.ValueType) || !isNum(right.ValueType) {
-		return Value{}, LanErrs.MustBeNumWithComparisonOp{node.Token}
+	order, err := compareValues(left, right, node.Token)
+	if err != nil {
+		return Value{}, err
 	}
 
-	switch left.ValueType {
-	case Integer:
-		if right.ValueType == Integer {
-			if intUncast(left.Value) < intUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if float64(intUncast(left.Value)) < DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		}
-
-	case Decimal:
-		if right.ValueType == Decimal {
-			if DecimalUncast(left.Value) < DecimalUncast(right.Value) {
-				return Value{Bool, 1}, nil
-			}
-		} else {
-			if DecimalUncast(left.Value) < float64(intUncast(right.Value)) {
-				return Value{Bool, 1}, nil
-			}
-		}
+	if order < 0 {
+		return Value{Bool, 1}, nil
 	}
 	return Value{Bool, 0}, nil
 }
@@ -898,28 +665,11 @@ func (node UnaryNode) Evaluate() (Value, error) {
 		return Value{}, err
 	}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
-			return Value{}, err
-		}
-		right = val
-	}
-
 	if node.Token.Text == "-" {
-		switch right.ValueType {
-		case Integer:
-			r := intUncast(right.Value)
-			r = r * -1
-			return intValue(r), nil
-
-		case Decimal:
-			r := DecimalUncast(right.Value)
-			r = r * -1
-			return DecimalValue(r), nil
+		if isNum(right.ValueType) {
+			return negateNum(right, node.Token)
 		}
-		return Value{}, errors.New("ERROR: Expected number type with unary negation token at line num :" +
-			strconv.Itoa(node.Token.LineNum) + ", cursor :" + strconv.Itoa(node.Token.Cursor))
+		return Value{}, LanErrs.UnaryTypeError{Token: node.Token, Expected: "a number"}
 	}
 
 	if right.ValueType == Bool {
@@ -928,8 +678,7 @@ func (node UnaryNode) Evaluate() (Value, error) {
 		}
 		return Value{Bool, 0}, nil
 	}
-	return Value{}, errors.New("ERROR: Expected type Bool with unary NOT token at line num :" +
-		strconv.Itoa(node.Token.LineNum) + ", cursor :" + strconv.Itoa(node.Token.Cursor))
+	return Value{}, LanErrs.UnaryTypeError{Token: node.Token, Expected: "a Bool"}
 }
 
 //For Variables
@@ -937,51 +686,110 @@ type IdentifierNode struct {
 	Token tokenizer.Token
 }
 
+//Returns the value of the variable from the nearest scope which has it. A constant which was folded gives its value
+//without being looked up
 func (node IdentifierNode) Evaluate() (Value, error) {
-	if _, ok := globalVars[node.Token.Text]; ok {
-		val := globalVars[node.Token.Text]
-		return val, nil
+	if value, ok := currentScope.folds[node.Token]; ok {
+		return value, nil
 	}
-	return identifierValue(node.Token.Text), nil
+	name := identifierName(node)
+	if s := currentScope.find(name); s != nil {
+		return s.vars[name], nil
+	}
+	return Value{}, noIdentifierError(node.Token, name)
+}
+
+//Identifier with a type written after it, e.g. `count: int`
+type TypeAnnotationNode struct {
+	Token      tokenizer.Token
+	Identifier Node
 }
 
-//Stores the varibales
-var globalVars = make(map[string]Value)
+func (node TypeAnnotationNode) Evaluate() (Value, error) {
+	return node.Identifier.Evaluate()
+}
 
-//Gets variable from Global vars
-func getVar(name string) (Value, error) {
-	if _, ok := globalVars[name]; ok {
-		val := globalVars[name]
-		return val, nil
+//Names used in type annotations
+var typeNames = map[string]valueKind{
+	"int":      Integer,
+	"decimal":  Decimal,
+	"float":    Float,
+	"string":   str,
+	"bool":     Bool,
+	"list":     List,
+	"map":      Map,
+	"time":     Time,
+	"duration": Duration,
+	"function": Function,
+}
+
+func kindName(v valueKind) string {
+	switch v {
+	case BigInteger:
+		return "int"
+	case Nil:
+		return "nil"
+	case Function:
+		return "function"
+	case Module:
+		return "module"
+	case Error:
+		return "error"
+	case Record:
+		return "record"
+	case Enum:
+		return "enum"
+	}
+	for name, kind := range typeNames {
+		if kind == v {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+//Numbers can be given to a variable with a wider number type, int to decimal or float and decimal to float
+func canWiden(from valueKind, to valueKind) bool {
+	switch to {
+	case Decimal:
+		return isInt(from)
+	case Float:
+		return isInt(from) || from == Decimal
 	}
-	return Value{}, LanErrs.NoIdentifierAvailableError{name}
+	return false
 }
 
-//Sets a var
-func setVar(name string, value Value) {
-	globalVars[name] = value
+func widen(value Value, to valueKind) Value {
+	if to == Float {
+		return FloatValue(asFloat(value))
+	}
+	return decimalValue(asDecimal(value))
 }
 
-//used to store an index into the Global array which stores varible names
-func identifierValue(val string) Value {
-	u, err := strconv.ParseUint(val, 10, 64)
-	if err == nil {
-		return Value{ValueType: Identifier, Value: u}
+//Checks a value against the declared type of a variable. Numbers are widened when they can be and any variable can hold nil
+func checkVarType(s *scope, name string, value Value, token tokenizer.Token) (Value, error) {
+	kind, ok := s.types[name]
+	if !ok || kind == value.ValueType || kind == Integer && value.ValueType == BigInteger || value.ValueType == Nil {
+		return value, nil
 	}
-	return Value{}
+	if canWiden(value.ValueType, kind) {
+		return widen(value, kind), nil
+	}
+	return Value{}, LanErrs.TypeMismatchError{Token: token, Identifier: name, Expected: kindName(kind), Got: kindName(value.ValueType)}
 }
 
-//used to do all the work of getting a varible - means less code in each Evaluate node method
-func fetchVar(v uint64) (Value, error) {
-	s := Global.GlobalVarNames[v]
-	val, err := getVar(s)
-	if err != nil {
-		return Value{}, err
+//Gets the identifier on the left of an assignment, it may have a type annotation
+func assignedIdentifier(node Node, token tokenizer.Token) (IdentifierNode, error) {
+	switch left := node.(type) {
+	case IdentifierNode:
+		return left, nil
+	case TypeAnnotationNode:
+		return assignedIdentifier(left.Identifier, token)
 	}
-	return val, nil
+	return IdentifierNode{}, LanErrs.AssignmentSyntaxError{Token: token, Reason: "expected a name on the left"}
 }
 
-//Used for assigning values to varibles
+//Used for declaring new varibles in the innermost scope
 type AssignmentNode struct {
 	Token tokenizer.Token
 	Left  Node
@@ -989,75 +797,164 @@ type AssignmentNode struct {
 }
 
 func (node AssignmentNode) Evaluate() (Value, error) {
-	left, err := node.Left.Evaluate()
-	if err != nil {
+	if _, err := assignedIdentifier(node.Left, node.Token); err != nil {
 		return Value{}, err
 	}
 	right, err := node.Right.Evaluate()
 	if err != nil {
 		return Value{}, err
 	}
+	return Value{}, declareVar(node.Left, right, node.Token)
+}
 
-	if right.ValueType == Identifier {
-		val, err := fetchVar(right.Value)
-		if err != nil {
+//Makes the variable on the left of := in the innermost scope
+func declareVar(left Node, right Value, token tokenizer.Token) error {
+	identifier, err := assignedIdentifier(left, token)
+	if err != nil {
+		return err
+	}
+
+	identifierStr := identifierName(identifier)
+	if currentScope.constants[identifierStr] {
+		return LanErrs.ConstantError{Token: identifier.Token, Identifier: identifierStr}
+	}
+	if _, ok := currentScope.vars[identifierStr]; ok {
+		return LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: identifierStr}
+	}
+
+	if annotation, ok := left.(TypeAnnotationNode); ok {
+		kind, ok := typeNames[annotation.Token.Text]
+		if !ok {
+			return LanErrs.UnknownTypeError{Token: annotation.Token}
+		}
+		currentScope.types[identifierStr] = kind
+	}
+
+	right, err = checkVarType(currentScope, identifierStr, right, token)
+	if err != nil {
+		return err
+	}
+
+	currentScope.vars[identifierStr] = right
+
+	return nil
+}
+
+//Used for giving a new value to a variable which already exists, e.g. `count = count + 1`, or to a field of a record
+type ReassignmentNode struct {
+	Token tokenizer.Token
+	Left  Node
+	Right Node
+}
+
+func (node ReassignmentNode) Evaluate() (Value, error) {
+	if _, ok := node.Left.(MemberNode); !ok {
+		if _, err := assignedIdentifier(node.Left, node.Token); err != nil {
 			return Value{}, err
 		}
-		right = val
 	}
+	right, err := node.Right.Evaluate()
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{}, reassignVar(node.Left, right, node.Token)
+}
 
-	if left.ValueType != Identifier {
-		return Value{}, errors.New("ERROR: Expected identifier on the left of assignment at line num :" +
-			strconv.Itoa(node.Token.LineNum) + ", cursor : " + strconv.Itoa(node.Token.Cursor))
+//Gives the nearest variable with the name on the left of = a new value, or a field of a record
+func reassignVar(left Node, right Value, token tokenizer.Token) error {
+	if member, ok := left.(MemberNode); ok {
+		return member.assign(right, token)
+	}
+	identifier, err := assignedIdentifier(left, token)
+	if err != nil {
+		return err
 	}
 
-	identifierStr := Global.GlobalVarNames[left.Value]
+	identifierStr := identifierName(identifier)
 
-	setVar(identifierStr, right)
+	s := currentScope.find(identifierStr)
+	if s == nil || s == builtinScope {
+		return noIdentifierError(identifier.Token, identifierStr)
+	}
+	if s.constants[identifierStr] {
+		return LanErrs.ConstantError{Token: identifier.Token, Identifier: identifierStr}
+	}
 
-	return Value{}, nil
+	right, err = checkVarType(s, identifierStr, right, token)
+	if err != nil {
+		return err
+	}
+
+	s.vars[identifierStr] = right
+
+	return nil
 }
 
+//Prints values separated by commas, e.g. `print a, b, sep=", ", end=""`. sep goes between the values and
+//end goes after them, they default to a space and a new line
 type PrintNode struct {
 	Token tokenizer.Token
 	Right Node
 }
 
 func (node PrintNode) Evaluate() (Value, error) {
-	right, err := node.Right.Evaluate()
-	if err != nil {
-		return Value{}, err
+	items := []Node{node.Right}
+	if tuple, ok := node.Right.(TupleNode); ok {
+		items = tuple.Items
 	}
 
-	if right.ValueType == Identifier {
-		this, err := fetchVar(right.Value)
+	options := map[string]string{"sep": " ", "end": "\n"}
+	var texts []string
+	for i, item := range items {
+		if name, value, ok := printOption(item); ok && i > 0 {
+			option, err := value.Evaluate()
+			if err != nil {
+				return Value{}, err
+			}
+			if option.ValueType != str {
+				return Value{}, LanErrs.WrongArgumentError{Token: node.Token, Function: "print", Expected: "a string for " + name,
+					Got: kindName(option.ValueType)}
+			}
+			options[name] = Global.Strings[option.Value]
+			continue
+		}
+
+		value, err := item.Evaluate()
 		if err != nil {
 			return Value{}, err
 		}
-		right = this
+		texts = append(texts, valueString(value))
 	}
 
-	switch right.ValueType {
-	case Integer:
-		i := intUncast(right.Value)
-		fmt.Println(i)
-
-	case Decimal:
-		i := DecimalUncast(right.Value)
-		fmt.Println(i)
-
-	case Bool:
-		if right.Value == 1 {
-			fmt.Println("True")
-		} else {
-			fmt.Println("False")
-		}
+	fmt.Fprint(settings.Stdout, strings.Join(texts, options["sep"])+options["end"])
+	return Value{}, nil
+}
 
-	case str:
-		s := Global.Strings[right.Value]
-		fmt.Println(s)
+//Finds sep=... and end=... in the values given to print
+func printOption(item Node) (string, Node, bool) {
+	equal, ok := item.(DoesEqualNode)
+	if !ok {
+		return "", nil, false
 	}
-	return Value{}, nil
+	identifier, ok := equal.Left.(IdentifierNode)
+	if !ok {
+		return "", nil, false
+	}
+	name := identifierName(identifier)
+	if name != "sep" && name != "end" {
+		return "", nil, false
+	}
+	return name, equal.Right, true
+}
+
+//Values joined by commas. They are taken apart by the statement using them, such as print
+type TupleNode struct {
+	Token tokenizer.Token
+	Items []Node
+}
+
+func (node TupleNode) Evaluate() (Value, error) {
+	return Value{}, LanErrs.UnexpectedCommaError{Token: node.Token}
 }
 
 //Control Flow
@@ -1079,11 +976,8 @@ func (node IfNode) Evaluate() (Value, error) {
 	}
 
 	if left.Value == 1 {
-		for _, statement := range node.Statements {
-			_, err := statement.Evaluate()
-			if err != nil {
-				return Value{}, err
-			}
+		if err := evaluateBlock(node.Statements); err != nil {
+			return Value{}, err
 		}
 	}
 
@@ -1107,11 +1001,8 @@ func (node WhileNode) Evaluate() (Value, error) {
 	}
 
 	for left.Value == 1 {
-		for _, statement := range node.Statements {
-			_, err := statement.Evaluate()
-			if err != nil {
-				return Value{}, err
-			}
+		if err := evaluateBlock(node.Statements); err != nil {
+			return Value{}, err
 		}
 		left, err = node.Expression.Evaluate()
 		if err != nil {
@@ -1125,6 +1016,8 @@ func (node WhileNode) Evaluate() (Value, error) {
 	return Value{}, nil
 }
 
+//input gives the whole line as a string. inputInt and inputDecimal ask again until a number is typed.
+//All of them give nil when there is nothing left to read
 type InputNode struct {
 	Token tokenizer.Token
 	Right Node
@@ -1135,21 +1028,35 @@ func (node InputNode) Evaluate() (Value, error) {
 	if err != nil {
 		return Value{}, err
 	}
-	r := Global.Strings[right.Value]
-	fmt.Println(r)
 
-	var input string
-	fmt.Scanln(&input)
-	if len(input) == 0 {
-		input = ""
-	}
-	Global.Strings = append(Global.Strings, input)
-	address := len(Global.Strings) - 1
-	val, err := stringValue(strconv.Itoa(address))
-	if err != nil {
-		return Value{}, err
+	for {
+		fmt.Fprintln(settings.Stdout, valueString(right))
+
+		line, err := readLine()
+		if err == io.EOF {
+			return Value{ValueType: Nil}, nil
+		}
+		if err != nil {
+			return Value{}, LanErrs.InputError{Token: node.Token, Reason: err.Error()}
+		}
+
+		switch node.Token.Text {
+		case "inputInt":
+			if number, err := toInt([]Value{newString(line)}, node.Token); err == nil {
+				return number, nil
+			}
+			fmt.Fprintln(settings.Stdout, "Please enter a whole number")
+
+		case "inputDecimal":
+			if number, err := toDecimal([]Value{newString(line)}, node.Token); err == nil {
+				return number, nil
+			}
+			fmt.Fprintln(settings.Stdout, "Please enter a number")
+
+		default:
+			return newString(line), nil
+		}
 	}
-	return val, nil
 }
 
 type DelNode struct {
@@ -1158,15 +1065,26 @@ type DelNode struct {
 }
 
 func (node DelNode) Evaluate() (Value, error) {
-	right, err := node.Right.Evaluate()
-	if err != nil {
-		return Value{}, err
-	}
-
-	if right.ValueType != Identifier {
+	identifier, ok := node.Right.(IdentifierNode)
+	if !ok {
 		return Value{}, LanErrs.ExpectedIdentifierError{node.Token}
 	}
-	index := Global.GlobalVarNames[right.Value]
-	delete(globalVars, index)
+	index := identifierName(identifier)
+	s := currentScope.find(index)
+	if s == nil || s == builtinScope {
+		return Value{}, noIdentifierError(identifier.Token, index)
+	}
+	if s.record {
+		return Value{}, LanErrs.DeleteFieldError{Token: identifier.Token, Field: index}
+	}
+	if s.constants[index] {
+		return Value{}, LanErrs.ConstantError{Token: identifier.Token, Identifier: index}
+	}
+	delete(s.vars, index)
+	delete(s.types, index)
+	//The variable hid a builtin, such as the math module, which doesn't come back when it is deleted
+	if _, ok := builtinScope.vars[index]; ok {
+		s.hidden[index] = true
+	}
 	return Value{}, nil
 }
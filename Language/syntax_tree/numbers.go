@@ -0,0 +1,199 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math"
+	"math/big"
+)
+
+// Numbers follow the same rules in every operation:
+//   int with int gives an int (apart from / which gives a decimal)
+//   int or decimal with decimal gives an exact decimal
+//   anything with a float gives a float
+
+// Decimals are kept in this list and the Value holds their index, in the same way as big ints
+var decimals []decimal.Decimal
+
+func decimalValue(d decimal.Decimal) Value {
+	decimals = append(decimals, d)
+	return Value{ValueType: Decimal, Value: uint64(len(decimals) - 1)}
+}
+
+// Gives any number value as a decimal
+func asDecimal(v Value) decimal.Decimal {
+	switch v.ValueType {
+	case Integer, BigInteger:
+		return decimal.FromInt(asBigInt(v))
+	case Float:
+		d, err := decimal.FromFloat(FloatUncast(v.Value))
+		if err != nil {
+			return decimal.FromInt(new(big.Int))
+		}
+		return d
+	}
+	return decimals[v.Value]
+}
+
+// Gives any number value as a float
+func asFloat(v Value) float64 {
+	switch v.ValueType {
+	case Integer:
+		return float64(intUncast(v.Value))
+	case BigInteger:
+		f, _ := new(big.Float).SetInt(asBigInt(v)).Float64()
+		return f
+	case Decimal:
+		return decimals[v.Value].Float64()
+	}
+	return FloatUncast(v.Value)
+}
+
+func isFloat(left Value, right Value) bool {
+	return left.ValueType == Float || right.ValueType == Float
+}
+
+func isZero(v Value) bool {
+	switch v.ValueType {
+	case Integer:
+		return intUncast(v.Value) == 0
+	case Decimal:
+		return decimals[v.Value].Sign() == 0
+	case Float:
+		return FloatUncast(v.Value) == 0
+	}
+	return false
+}
+
+func addNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return addInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(asFloat(left) + asFloat(right)), nil
+	}
+	return decimalValue(asDecimal(left).Add(asDecimal(right))), nil
+}
+
+func subtractNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return subtractInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(asFloat(left) - asFloat(right)), nil
+	}
+	return decimalValue(asDecimal(left).Sub(asDecimal(right))), nil
+}
+
+func multiplyNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return multiplyInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(asFloat(left) * asFloat(right)), nil
+	}
+	return decimalValue(asDecimal(left).Mul(asDecimal(right))), nil
+}
+
+// Division with / never drops the part after the decimal point, so two ints give a decimal
+func divideNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if isZero(right) {
+		return Value{}, LanErrs.DivisionByZeroError{Token: token}
+	}
+	if isFloat(left, right) {
+		return FloatValue(asFloat(left) / asFloat(right)), nil
+	}
+	return decimalValue(asDecimal(left).Quo(asDecimal(right), settings.DecimalPrecision, settings.Rounding)), nil
+}
+
+func intDivideNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isZero(right):
+		return Value{}, LanErrs.DivisionByZeroError{Token: token}
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return divideInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(math.Trunc(asFloat(left) / asFloat(right))), nil
+	}
+	return decimalValue(asDecimal(left).QuoTrunc(asDecimal(right))), nil
+}
+
+func moduloNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isZero(right):
+		return Value{}, LanErrs.DivisionByZeroError{Token: token}
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return moduloInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(math.Mod(asFloat(left), asFloat(right))), nil
+	}
+	return decimalValue(asDecimal(left).Rem(asDecimal(right))), nil
+}
+
+// Powers which are whole numbers are worked out exactly. Other powers of decimals go through a float
+// so are only as exact as a float
+func powerNums(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case isInt(left.ValueType) && isInt(right.ValueType) && asBigInt(right).Sign() >= 0:
+		return powerInts(left, right, token)
+	case isFloat(left, right):
+		return FloatValue(math.Pow(asFloat(left), asFloat(right))), nil
+	}
+
+	exponent := asDecimal(right)
+	if !exponent.IsInteger() {
+		answer, err := decimal.FromFloat(math.Pow(asFloat(left), asFloat(right)))
+		if err != nil {
+			return Value{}, LanErrs.NotARealNumberError{Token: token}
+		}
+		return decimalValue(answer), nil
+	}
+	if isZero(left) && exponent.Sign() < 0 {
+		return Value{}, LanErrs.DivisionByZeroError{Token: token}
+	}
+	answer, err := asDecimal(left).PowInt(exponent.BigInt(), settings.DecimalPrecision, settings.Rounding)
+	if err != nil {
+		return Value{}, LanErrs.PowerTooBigError{Token: token}
+	}
+	return decimalValue(answer), nil
+}
+
+func negateNum(right Value, token tokenizer.Token) (Value, error) {
+	switch right.ValueType {
+	case Integer, BigInteger:
+		return negateInt(right, token)
+	case Float:
+		return FloatValue(-FloatUncast(right.Value)), nil
+	}
+	return decimalValue(asDecimal(right).Neg()), nil
+}
+
+// Compares two numbers, giving -1 when left is smaller, 0 when they are the same and 1 when left is bigger
+func compareNums(left Value, right Value) int {
+	switch {
+	case left.ValueType == Integer && right.ValueType == Integer:
+		l, r := intUncast(left.Value), intUncast(right.Value)
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		}
+		return 0
+
+	case isInt(left.ValueType) && isInt(right.ValueType):
+		return asBigInt(left).Cmp(asBigInt(right))
+
+	case isFloat(left, right):
+		l, r := asFloat(left), asFloat(right)
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		}
+		return 0
+	}
+	return asDecimal(left).Cmp(asDecimal(right))
+}
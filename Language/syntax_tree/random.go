@@ -0,0 +1,92 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+// Every function in the random module uses this generator, so setting its seed repeats the same numbers
+var generator = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// Number of digits after the decimal point in the decimals given by random.decimal
+const randomDecimalDigits = 16
+
+func seedRandom() {
+	if settings.FixedSeed {
+		generator.Seed(settings.Seed)
+		return
+	}
+	generator.Seed(time.Now().UnixNano())
+}
+
+func newRandomModule() Value {
+	members := map[string]Value{}
+	for _, f := range []function{
+		{name: "int", minArgs: 2, maxArgs: 2, call: randomInt},
+		{name: "decimal", minArgs: 0, maxArgs: 0, call: randomDecimal},
+		{name: "choice", minArgs: 1, maxArgs: 1, call: randomChoice},
+		{name: "shuffle", minArgs: 1, maxArgs: 1, call: randomShuffle},
+		{name: "seed", minArgs: 1, maxArgs: 1, call: randomSeed},
+	} {
+		members[f.name] = functionValue(function{name: "random." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, call: f.call})
+	}
+	return moduleValue("random", members)
+}
+
+// A whole number from a to b, including a and b
+func randomInt(args []Value, token tokenizer.Token) (Value, error) {
+	for _, arg := range args {
+		if !isInt(arg.ValueType) {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "random.int", Expected: "an int", Got: kindName(arg.ValueType)}
+		}
+	}
+	low, high := asBigInt(args[0]), asBigInt(args[1])
+	if low.Cmp(high) > 0 {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "random.int", Expected: "a first number which isn't bigger than the second",
+			Got: low.String() + " and " + high.String()}
+	}
+
+	size := new(big.Int).Sub(high, low)
+	size.Add(size, big.NewInt(1))
+	return bigIntValue(new(big.Int).Add(low, new(big.Int).Rand(generator, size)), token)
+}
+
+// A decimal from 0 up to but not including 1
+func randomDecimal(args []Value, token tokenizer.Token) (Value, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(randomDecimalDigits), nil)
+	return decimalValue(decimal.New(new(big.Int).Rand(generator, limit), randomDecimalDigits)), nil
+}
+
+// One item of the list picked at random
+func randomChoice(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != List || len(lists[args[0].Value]) == 0 {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "random.choice", Expected: "a list which isn't empty",
+			Got: describeValue(args[0])}
+	}
+	items := lists[args[0].Value]
+	return items[generator.Intn(len(items))], nil
+}
+
+// Puts the items of the list in a random order. The list itself is changed
+func randomShuffle(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != List {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "random.shuffle", Expected: "a list", Got: kindName(args[0].ValueType)}
+	}
+	items := lists[args[0].Value]
+	generator.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+	return Value{ValueType: Nil}, nil
+}
+
+func randomSeed(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != Integer {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "random.seed", Expected: "an int", Got: kindName(args[0].ValueType)}
+	}
+	generator.Seed(int64(intUncast(args[0].Value)))
+	return Value{ValueType: Nil}, nil
+}
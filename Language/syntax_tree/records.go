@@ -0,0 +1,190 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/tokenizer"
+	"strings"
+)
+
+// Records are kept in this list and the Value holds their index, so every copy of a record Value shares its
+// fields in the same way as lists
+var records []record
+
+// The fields of a record are kept in a scope, so a method can use them by name. The scope's parent is the scope
+// the record was declared in
+type record struct {
+	typ    *recordType
+	fields *scope
+}
+
+// What a record declaration says. Fields are kept in the order they were written, so they are printed and
+// given to the constructor in that order
+type recordType struct {
+	name    string
+	fields  []recordField
+	methods map[string]FunctionNode
+	closure *scope
+}
+
+type recordField struct {
+	name  string
+	kind  valueKind
+	typed bool
+}
+
+func recordValue(r record) Value {
+	records = append(records, r)
+	return Value{ValueType: Record, Value: uint64(len(records) - 1)}
+}
+
+// Declares a record, e.g. record Person { name: string, age: int }. The name becomes a function which makes a
+// record, its arguments are the fields in order and they can also be given by name, e.g.
+// Person("Ann", age=30). Fields which aren't given are nil
+type RecordNode struct {
+	Token      tokenizer.Token
+	Name       Node
+	Statements []Node
+}
+
+func (node RecordNode) Evaluate() (Value, error) {
+	typ, identifier, err := node.recordType()
+	if err != nil {
+		return Value{}, err
+	}
+	if _, ok := currentScope.vars[typ.name]; ok {
+		return Value{}, LanErrs.AlreadyDeclaredError{Token: identifier.Token, Identifier: typ.name}
+	}
+	typ.closure = currentScope
+
+	names := make([]string, len(typ.fields))
+	for i, field := range typ.fields {
+		names[i] = field.name
+	}
+	constructor := functionValue(function{name: typ.name, minArgs: 0, maxArgs: len(names), named: names,
+		call: func(args []Value, token tokenizer.Token) (Value, error) {
+			return typ.construct(args, token)
+		}})
+	currentScope.vars[typ.name] = constructor
+	return constructor, nil
+}
+
+// Reads the fields and methods in the block. Each statement must be a field, which can have a type, or a
+// method declared with fn
+func (node RecordNode) recordType() (*recordType, IdentifierNode, error) {
+	identifier, ok := node.Name.(IdentifierNode)
+	if !ok {
+		return nil, IdentifierNode{}, LanErrs.RecordSyntaxError{Token: node.Token, Reason: "record must be followed by a name"}
+	}
+	typ := &recordType{name: identifierName(identifier), methods: map[string]FunctionNode{}}
+	used := map[string]bool{}
+
+	for _, statement := range node.Statements {
+		var name IdentifierNode
+		switch statement := statement.(type) {
+		case IdentifierNode:
+			name = statement
+			typ.fields = append(typ.fields, recordField{name: identifierName(name)})
+
+		case TypeAnnotationNode:
+			name, ok = statement.Identifier.(IdentifierNode)
+			if !ok {
+				return nil, identifier, LanErrs.RecordSyntaxError{Token: statement.Token, Reason: "a field must be a name"}
+			}
+			kind, ok := typeNames[statement.Token.Text]
+			if !ok {
+				return nil, identifier, LanErrs.UnknownTypeError{Token: statement.Token}
+			}
+			typ.fields = append(typ.fields, recordField{name: identifierName(name), kind: kind, typed: true})
+
+		case FunctionNode:
+			var err error
+			if name, _, err = statement.signature(); err != nil {
+				return nil, identifier, err
+			}
+			typ.methods[identifierName(name)] = statement
+
+		default:
+			return nil, identifier, LanErrs.RecordSyntaxError{Token: node.Token,
+				Reason: "the block can only have fields and methods declared with fn"}
+		}
+
+		if used[identifierName(name)] {
+			return nil, identifier, LanErrs.RecordSyntaxError{Token: name.Token,
+				Reason: "\"" + identifierName(name) + "\" is declared more than once"}
+		}
+		used[identifierName(name)] = true
+	}
+	return typ, identifier, nil
+}
+
+// Makes a record from the values given to the constructor, each is checked against the type of its field
+func (typ *recordType) construct(args []Value, token tokenizer.Token) (Value, error) {
+	fields := newScope(typ.closure)
+	fields.record = true
+	for i, field := range typ.fields {
+		value := Value{ValueType: Nil}
+		if i < len(args) {
+			value = args[i]
+		}
+		if field.typed {
+			fields.types[field.name] = field.kind
+		}
+		value, err := checkVarType(fields, field.name, value, token)
+		if err != nil {
+			return Value{}, err
+		}
+		fields.vars[field.name] = value
+	}
+	return recordValue(record{typ: typ, fields: fields}), nil
+}
+
+// Gets a field or a method of a record. A method is given with the record as its receiver, it can use the
+// fields by name and the record itself as self
+func (r record) member(name string, receiver Value) (Value, bool) {
+	if value, ok := r.fields.vars[name]; ok {
+		return value, true
+	}
+	method, ok := r.typ.methods[name]
+	if !ok {
+		return Value{}, false
+	}
+	_, params, _ := method.signature()
+	self := newScope(r.fields)
+	self.vars["self"] = receiver
+	return userFunction(r.typ.name+"."+name, params, self, method.run), true
+}
+
+// Gives a field a new value, e.g. person.age = 31
+func (r record) setField(name string, value Value, token tokenizer.Token, nameToken tokenizer.Token) error {
+	if _, ok := r.fields.vars[name]; !ok {
+		return LanErrs.NoMemberError{Token: nameToken, Module: r.typ.name, Member: name}
+	}
+	value, err := checkVarType(r.fields, name, value, token)
+	if err != nil {
+		return err
+	}
+	r.fields.vars[name] = value
+	return nil
+}
+
+// A record is written with the names of its fields, e.g. Person(name="Ann", age=30)
+func (r record) String() string {
+	fields := make([]string, len(r.typ.fields))
+	for i, field := range r.typ.fields {
+		fields[i] = field.name + "=" + describeValue(r.fields.vars[field.name])
+	}
+	return r.typ.name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+// Two records are equal when they were made by the same declaration and all of their fields are equal
+func recordsEqual(left record, right record) bool {
+	if left.typ != right.typ {
+		return false
+	}
+	for _, field := range left.typ.fields {
+		if !valuesEqual(left.fields.vars[field.name], right.fields.vars[field.name]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package tree
+
+import (
+	"errors"
+	"language/LanErrs"
+	"language/tokenizer"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+)
+
+// Patterns are only compiled the first time they are used
+var patterns = map[string]*regexp.Regexp{}
+
+// The re module. Patterns use Go's RE2 syntax, so there are no backreferences and matching always takes time
+// in proportion to the length of the string
+func newRegexModule() Value {
+	members := map[string]Value{}
+	for _, f := range []function{
+		{name: "match", minArgs: 2, maxArgs: 2, call: regexMatch},
+		{name: "find", minArgs: 2, maxArgs: 2, call: regexFind},
+		{name: "findAll", minArgs: 2, maxArgs: 2, call: regexFindAll},
+		{name: "replace", minArgs: 3, maxArgs: 3, call: regexReplace},
+		{name: "split", minArgs: 2, maxArgs: 3, call: regexSplit},
+	} {
+		members[f.name] = functionValue(function{name: "re." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, call: f.call})
+	}
+	return moduleValue("re", members)
+}
+
+func compilePattern(pattern string, token tokenizer.Token) (*regexp.Regexp, error) {
+	if re, ok := patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		reason := err.Error()
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) {
+			reason = syntaxErr.Code.String()
+			if syntaxErr.Expr != pattern {
+				reason += " in " + strconv.Quote(syntaxErr.Expr)
+			}
+		}
+		return nil, LanErrs.PatternError{Token: token, Pattern: pattern, Reason: reason}
+	}
+	patterns[pattern] = re
+	return re, nil
+}
+
+// Gets the pattern and the string given to a function in the re module
+func patternArgs(args []Value, function string, token tokenizer.Token) (*regexp.Regexp, string, error) {
+	pattern, err := stringArg(args, 0, function, token)
+	if err != nil {
+		return nil, "", err
+	}
+	re, err := compilePattern(pattern, token)
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := stringArg(args, 1, function, token)
+	return re, s, err
+}
+
+// True when the whole string matches the pattern
+func regexMatch(args []Value, token tokenizer.Token) (Value, error) {
+	re, s, err := patternArgs(args, "re.match", token)
+	if err != nil {
+		return Value{}, err
+	}
+	whole, err := compilePattern("^(?:"+re.String()+")$", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return boolValue(whole.MatchString(s)), nil
+}
+
+// The first match as a list of the matched text followed by each group, or nil when nothing matches
+func regexFind(args []Value, token tokenizer.Token) (Value, error) {
+	re, s, err := patternArgs(args, "re.find", token)
+	if err != nil {
+		return Value{}, err
+	}
+	match := re.FindStringSubmatchIndex(s)
+	if match == nil {
+		return Value{ValueType: Nil}, nil
+	}
+	return matchList(s, match), nil
+}
+
+// A list with a list like the one re.find gives for each match
+func regexFindAll(args []Value, token tokenizer.Token) (Value, error) {
+	re, s, err := patternArgs(args, "re.findAll", token)
+	if err != nil {
+		return Value{}, err
+	}
+	items := []Value{}
+	for _, match := range re.FindAllStringSubmatchIndex(s, -1) {
+		items = append(items, matchList(s, match))
+	}
+	return listValue(items), nil
+}
+
+// A group which didn't take part in the match is nil
+func matchList(s string, match []int) Value {
+	items := make([]Value, len(match)/2)
+	for i := range items {
+		start, end := match[2*i], match[2*i+1]
+		if start < 0 {
+			items[i] = Value{ValueType: Nil}
+			continue
+		}
+		items[i] = newString(s[start:end])
+	}
+	return listValue(items)
+}
+
+// Replaces every match. $1 or ${name} in the replacement is the text of that group
+func regexReplace(args []Value, token tokenizer.Token) (Value, error) {
+	re, s, err := patternArgs(args, "re.replace", token)
+	if err != nil {
+		return Value{}, err
+	}
+	replacement, err := stringArg(args, 2, "re.replace", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(re.ReplaceAllString(s, replacement)), nil
+}
+
+// split(pattern, s) gives the parts of s between the matches, split(pattern, s, n) gives at most n parts
+func regexSplit(args []Value, token tokenizer.Token) (Value, error) {
+	re, s, err := patternArgs(args, "re.split", token)
+	if err != nil {
+		return Value{}, err
+	}
+	n := -1
+	if len(args) == 3 {
+		if args[2].ValueType != Integer || intUncast(args[2].Value) < 1 {
+			return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "re.split", Expected: "an int above 0",
+				Got: describeValue(args[2])}
+		}
+		n = intUncast(args[2].Value)
+	}
+	return stringList(re.Split(s, n)), nil
+}
@@ -0,0 +1,118 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+)
+
+// Variables live in a chain of scopes. Bodies of if and while statements get their own scope
+// which is thrown away when the body finishes
+type scope struct {
+	vars   map[string]Value
+	types  map[string]valueKind
+	parent *scope
+	// The scope holds the fields of a record, which can be given new values but not deleted
+	record bool
+	// Variables declared with const, they can't be given new values or deleted
+	constants map[string]bool
+	// The constants of the file the scope is in which can be folded, shared with every scope made inside it
+	folds Folds
+	// Builtins hidden by a variable which was deleted, they stay hidden until the scope ends
+	hidden map[string]bool
+}
+
+func newScope(parent *scope) *scope {
+	s := &scope{vars: make(map[string]Value), types: make(map[string]valueKind), parent: parent,
+		constants: make(map[string]bool), hidden: make(map[string]bool)}
+	if parent != nil {
+		s.folds = parent.folds
+	}
+	return s
+}
+
+// The innermost scope, variables declared with := are put in here. The global scope sits below the builtins
+var currentScope = newScope(builtinScope)
+
+func pushScope() {
+	currentScope = newScope(currentScope)
+}
+
+func popScope() {
+	currentScope = currentScope.parent
+}
+
+// Finds the nearest scope which has a variable with the given name, returns nil if there isn't one
+func (s *scope) find(name string) *scope {
+	for current := s; current != nil; current = current.parent {
+		if _, ok := current.vars[name]; ok {
+			return current
+		}
+		if current.hidden[name] {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Runs the statements of a block inside a new scope
+func evaluateBlock(statements []Node) error {
+	pushScope()
+	defer popScope()
+
+	for _, statement := range statements {
+		if _, err := statement.Evaluate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func noIdentifierError(token tokenizer.Token, name string) error {
+	return LanErrs.NoIdentifierAvailableError{Token: token, Identifier: name, Suggestion: closestVarName(name)}
+}
+
+// Finds the variable name used in the program which is most like the given one, used to point out typos.
+// Returns "" when no name is close enough
+func closestVarName(name string) string {
+	closest := ""
+	bestDistance := len([]rune(name))/3 + 1
+	for _, varName := range Global.GlobalVarNames {
+		if varName == name {
+			continue
+		}
+		if distance := editDistance(name, varName); distance < bestDistance {
+			closest, bestDistance = varName, distance
+		}
+	}
+	return closest
+}
+
+// Levenshtein distance between two strings
+func editDistance(a string, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(second)]
+}
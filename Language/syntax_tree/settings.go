@@ -0,0 +1,66 @@
+package tree
+
+import (
+	"bufio"
+	"io"
+	"language/decimal"
+	"os"
+	"strings"
+)
+
+// Settings which change how programs are run. The interpreter sets these before running a program
+type Settings struct {
+	//Give an IntegerOverflowError when an int gets too big instead of moving it to a big int
+	StrictIntegers bool
+	//Number of significant digits kept when a decimal division isn't exact
+	DecimalPrecision int
+	//How decimals are rounded when digits are dropped
+	Rounding decimal.RoundingMode
+	//Where input reads lines from
+	Stdin io.Reader
+	//Where print, input prompts and errors are written
+	Stdout io.Writer
+	//Start the random module from Seed instead of the time so it gives the same numbers every run
+	FixedSeed bool
+	Seed      int64
+	//The directory the file builtins can use. Files outside of it can't be used, and when it is "" no files can be used
+	FileRoot string
+	//Where time.now and time.sleep get the time from
+	Clock Clock
+	//The file the program was read from, imports in it are found relative to its directory
+	ScriptPath string
+	//Directories searched for imports which aren't found relative to the importing file
+	ImportPath []string
+	//Reads and parses the file of an imported module, the interpreter sets this
+	Parse func(path string) ([]Node, []error)
+}
+
+// The settings used when the interpreter isn't given any options
+func DefaultSettings() Settings {
+	return Settings{DecimalPrecision: 28, Rounding: decimal.HalfEven, Stdin: os.Stdin, Stdout: os.Stdout,
+		Clock: systemClock{}}
+}
+
+var settings = DefaultSettings()
+
+// Stdin is read through this so input can read a line at a time
+var stdin = bufio.NewReader(settings.Stdin)
+
+func Configure(s Settings) {
+	settings = s
+	stdin = bufio.NewReader(s.Stdin)
+	seedRandom()
+	resetImports()
+}
+
+// Reads one line from the input without the line ending. io.EOF is only given when there is nothing left to read
+func readLine() (string, error) {
+	line, err := stdin.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,200 @@
+package tree
+
+import (
+	"language/Global"
+	"language/LanErrs"
+	"language/tokenizer"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Gets a string argument given to a builtin
+func stringArg(args []Value, i int, function string, token tokenizer.Token) (string, error) {
+	if args[i].ValueType != str {
+		return "", LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a string", Got: kindName(args[i].ValueType)}
+	}
+	return Global.Strings[args[i].Value], nil
+}
+
+// The number of characters in a string, items in a list or keys in a map
+func length(args []Value, token tokenizer.Token) (Value, error) {
+	switch args[0].ValueType {
+	case str:
+		return intValue(utf8.RuneCountInString(Global.Strings[args[0].Value])), nil
+	case List:
+		return intValue(len(lists[args[0].Value])), nil
+	case Map:
+		return intValue(len(maps[args[0].Value].keys)), nil
+	}
+	return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "len", Expected: "a string, list or map", Got: kindName(args[0].ValueType)}
+}
+
+func upper(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "upper", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(strings.ToUpper(s)), nil
+}
+
+func lower(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "lower", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(strings.ToLower(s)), nil
+}
+
+// Removes spaces, tabs and new lines from both ends
+func trim(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "trim", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(strings.TrimSpace(s)), nil
+}
+
+// Splits a string into a list of strings. Without a separator it splits on spaces, with "" it gives each character
+func split(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "split", token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var parts []string
+	if len(args) == 1 {
+		parts = strings.Fields(s)
+	} else {
+		separator, err := stringArg(args, 1, "split", token)
+		if err != nil {
+			return Value{}, err
+		}
+		parts = strings.Split(s, separator)
+	}
+
+	items := make([]Value, len(parts))
+	for i, part := range parts {
+		items[i] = newString(part)
+	}
+	return listValue(items), nil
+}
+
+// Joins the items of a list into one string with the separator between them, e.g. join(names, ", ")
+func join(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != List {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "join", Expected: "a list", Got: kindName(args[0].ValueType)}
+	}
+	separator, err := stringArg(args, 1, "join", token)
+	if err != nil {
+		return Value{}, err
+	}
+
+	items := make([]string, len(lists[args[0].Value]))
+	for i, item := range lists[args[0].Value] {
+		items[i] = valueString(item)
+	}
+	return newString(strings.Join(items, separator)), nil
+}
+
+// Checks if a string has a substring in it, a list has an item in it or a map has a key
+func contains(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType == Map {
+		if args[1].ValueType != str {
+			return boolValue(false), nil
+		}
+		_, ok := maps[args[0].Value].values[Global.Strings[args[1].Value]]
+		return boolValue(ok), nil
+	}
+	if args[0].ValueType == List {
+		for _, item := range lists[args[0].Value] {
+			if valuesEqual(item, args[1]) {
+				return Value{ValueType: Bool, Value: 1}, nil
+			}
+		}
+		return Value{ValueType: Bool, Value: 0}, nil
+	}
+
+	s, err := stringArg(args, 0, "contains", token)
+	if err != nil {
+		return Value{}, err
+	}
+	substring, err := stringArg(args, 1, "contains", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return boolValue(strings.Contains(s, substring)), nil
+}
+
+func startsWith(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "startsWith", token)
+	if err != nil {
+		return Value{}, err
+	}
+	prefix, err := stringArg(args, 1, "startsWith", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return boolValue(strings.HasPrefix(s, prefix)), nil
+}
+
+// Replaces every time old is found in the string, e.g. replace(s, "cat", "dog")
+func replace(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "replace", token)
+	if err != nil {
+		return Value{}, err
+	}
+	old, err := stringArg(args, 1, "replace", token)
+	if err != nil {
+		return Value{}, err
+	}
+	replacement, err := stringArg(args, 2, "replace", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(strings.ReplaceAll(s, old, replacement)), nil
+}
+
+// Gives the index of the first character of the substring, or -1 when it isn't in the string
+func find(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "find", token)
+	if err != nil {
+		return Value{}, err
+	}
+	substring, err := stringArg(args, 1, "find", token)
+	if err != nil {
+		return Value{}, err
+	}
+	index := strings.Index(s, substring)
+	if index < 0 {
+		return intValue(-1), nil
+	}
+	return intValue(utf8.RuneCountInString(s[:index])), nil
+}
+
+// The most bytes a string made by repeat can have
+const maxRepeatLength = 100000000
+
+func repeat(args []Value, token tokenizer.Token) (Value, error) {
+	s, err := stringArg(args, 0, "repeat", token)
+	if err != nil {
+		return Value{}, err
+	}
+	if args[1].ValueType != Integer || intUncast(args[1].Value) < 0 {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "repeat", Expected: "an int which isn't negative",
+			Got: describeValue(args[1])}
+	}
+	n := intUncast(args[1].Value)
+	if len(s) > 0 && n > maxRepeatLength/len(s) {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "repeat",
+			Expected: "a count which makes a string of at most " + strconv.Itoa(maxRepeatLength) + " bytes", Got: describeValue(args[1])}
+	}
+	return newString(strings.Repeat(s, n)), nil
+}
+
+func boolValue(b bool) Value {
+	if b {
+		return Value{ValueType: Bool, Value: 1}
+	}
+	return Value{ValueType: Bool, Value: 0}
+}
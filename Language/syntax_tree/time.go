@@ -0,0 +1,283 @@
+package tree
+
+import (
+	"language/LanErrs"
+	"language/decimal"
+	"language/tokenizer"
+	"math/big"
+	"time"
+)
+
+// Times are kept in this list and the Value holds their index, so they keep their time zone.
+// Durations are kept in the Value as a number of nanoseconds
+var times []time.Time
+
+// Where time.now and time.sleep get the time from. The interpreter.WithClock option gives a different one so
+// programs using the time can be tested
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func timeValue(t time.Time) Value {
+	times = append(times, t)
+	return Value{ValueType: Time, Value: uint64(len(times) - 1)}
+}
+
+func durationValue(d time.Duration) Value {
+	return Value{ValueType: Duration, Value: uint64(d)}
+}
+
+func durationUncast(val uint64) time.Duration {
+	return time.Duration(int64(val))
+}
+
+func isTime(v valueKind) bool {
+	return v == Time || v == Duration
+}
+
+// The time module. Layouts are written the way Go writes them, using the time 2006-01-02 15:04:05 in the zone
+// -0700, and default to RFC 3339 e.g. 2006-01-02T15:04:05Z07:00
+func newTimeModule() Value {
+	members := map[string]Value{
+		"millisecond": durationValue(time.Millisecond),
+		"second":      durationValue(time.Second),
+		"minute":      durationValue(time.Minute),
+		"hour":        durationValue(time.Hour),
+		"day":         durationValue(24 * time.Hour),
+	}
+	for _, f := range []function{
+		{name: "now", minArgs: 0, maxArgs: 0, call: timeNow},
+		{name: "unix", minArgs: 0, maxArgs: 1, call: timeUnix},
+		{name: "parse", minArgs: 1, maxArgs: 2, call: timeParse},
+		{name: "format", minArgs: 1, maxArgs: 2, call: timeFormat},
+		{name: "duration", minArgs: 1, maxArgs: 1, call: timeDuration},
+		{name: "ms", minArgs: 1, maxArgs: 1, call: timeMs},
+		{name: "sleep", minArgs: 1, maxArgs: 1, call: timeSleep},
+	} {
+		members[f.name] = functionValue(function{name: "time." + f.name, minArgs: f.minArgs, maxArgs: f.maxArgs, call: f.call})
+	}
+	return moduleValue("time", members)
+}
+
+func timeArg(args []Value, i int, function string, token tokenizer.Token) (time.Time, error) {
+	if args[i].ValueType != Time {
+		return time.Time{}, LanErrs.WrongArgumentError{Token: token, Function: function, Expected: "a time", Got: kindName(args[i].ValueType)}
+	}
+	return times[args[i].Value], nil
+}
+
+// Gets the layout argument at index i, or RFC 3339 when it isn't given
+func layoutArg(args []Value, i int, function string, token tokenizer.Token) (string, error) {
+	if i >= len(args) {
+		return time.RFC3339, nil
+	}
+	return stringArg(args, i, function, token)
+}
+
+func timeNow(args []Value, token tokenizer.Token) (Value, error) {
+	return timeValue(settings.Clock.Now()), nil
+}
+
+// unix() gives the number of seconds since 1970-01-01 00:00:00 UTC, unix(t) gives it for the time t
+func timeUnix(args []Value, token tokenizer.Token) (Value, error) {
+	t := settings.Clock.Now()
+	if len(args) == 1 {
+		var err error
+		if t, err = timeArg(args, 0, "time.unix", token); err != nil {
+			return Value{}, err
+		}
+	}
+	return bigIntValue(big.NewInt(t.Unix()), token)
+}
+
+// A time without a zone in it is UTC
+func timeParse(args []Value, token tokenizer.Token) (Value, error) {
+	text, err := stringArg(args, 0, "time.parse", token)
+	if err != nil {
+		return Value{}, err
+	}
+	layout, err := layoutArg(args, 1, "time.parse", token)
+	if err != nil {
+		return Value{}, err
+	}
+	t, err := time.Parse(layout, text)
+	if err != nil {
+		return Value{}, conversionError(args[0], "a time with the layout "+describeValue(newString(layout)), token)
+	}
+	return timeValue(t), nil
+}
+
+func timeFormat(args []Value, token tokenizer.Token) (Value, error) {
+	t, err := timeArg(args, 0, "time.format", token)
+	if err != nil {
+		return Value{}, err
+	}
+	layout, err := layoutArg(args, 1, "time.format", token)
+	if err != nil {
+		return Value{}, err
+	}
+	return newString(t.Format(layout)), nil
+}
+
+// Reads a duration such as "1h30m", "90s" or "250ms"
+func timeDuration(args []Value, token tokenizer.Token) (Value, error) {
+	text, err := stringArg(args, 0, "time.duration", token)
+	if err != nil {
+		return Value{}, err
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return Value{}, conversionError(args[0], "a duration", token)
+	}
+	return durationValue(d), nil
+}
+
+// The number of whole milliseconds in a duration
+func timeMs(args []Value, token tokenizer.Token) (Value, error) {
+	if args[0].ValueType != Duration {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "time.ms", Expected: "a duration", Got: kindName(args[0].ValueType)}
+	}
+	return bigIntValue(big.NewInt(durationUncast(args[0].Value).Milliseconds()), token)
+}
+
+// Waits for a number of milliseconds or a duration
+func timeSleep(args []Value, token tokenizer.Token) (Value, error) {
+	d := durationUncast(args[0].Value)
+	if args[0].ValueType != Duration {
+		ms, err := numberArg(args, 0, "time.sleep", token)
+		if err != nil {
+			return Value{}, err
+		}
+		if d, err = scaleDuration(time.Millisecond, ms, token); err != nil {
+			return Value{}, err
+		}
+	}
+	if d < 0 {
+		return Value{}, LanErrs.WrongArgumentError{Token: token, Function: "time.sleep", Expected: "a wait which isn't negative",
+			Got: describeValue(args[0])}
+	}
+	settings.Clock.Sleep(d)
+	return Value{ValueType: Nil}, nil
+}
+
+// Multiplies a duration by a number, rounding to the nearest nanosecond
+func scaleDuration(d time.Duration, n Value, token tokenizer.Token) (time.Duration, error) {
+	if !isFinite(n) {
+		return 0, LanErrs.TimeRangeError{Token: token}
+	}
+	scaled := asDecimal(n).Mul(decimal.FromInt(big.NewInt(int64(d)))).Round(0, decimal.HalfEven).BigInt()
+	if !scaled.IsInt64() {
+		return 0, LanErrs.TimeRangeError{Token: token}
+	}
+	return time.Duration(scaled.Int64()), nil
+}
+
+// Adds two durations or a time and a duration, the sum must still fit in a duration
+func addDurations(a time.Duration, b time.Duration, token tokenizer.Token) (time.Duration, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, LanErrs.TimeRangeError{Token: token}
+	}
+	return sum, nil
+}
+
+// A time + a duration gives a time, a duration + a duration gives a duration
+func addTimes(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case left.ValueType == Time && right.ValueType == Duration:
+		return timeValue(times[left.Value].Add(durationUncast(right.Value))), nil
+	case left.ValueType == Duration && right.ValueType == Time:
+		return timeValue(times[right.Value].Add(durationUncast(left.Value))), nil
+	case left.ValueType == Duration && right.ValueType == Duration:
+		d, err := addDurations(durationUncast(left.Value), durationUncast(right.Value), token)
+		return durationValue(d), err
+	}
+	return Value{}, LanErrs.IncompatibleTypeError{Token: token}
+}
+
+// A time - a time gives the duration between them, a time - a duration gives a time
+func subtractTimes(left Value, right Value, token tokenizer.Token) (Value, error) {
+	switch {
+	case left.ValueType == Time && right.ValueType == Time:
+		l, r := times[left.Value], times[right.Value]
+		d := l.Sub(r)
+		if !r.Add(d).Equal(l) {
+			return Value{}, LanErrs.TimeRangeError{Token: token}
+		}
+		return durationValue(d), nil
+	case left.ValueType == Time && right.ValueType == Duration:
+		return timeValue(times[left.Value].Add(-durationUncast(right.Value))), nil
+	case left.ValueType == Duration && right.ValueType == Duration:
+		d, err := addDurations(durationUncast(left.Value), -durationUncast(right.Value), token)
+		return durationValue(d), err
+	}
+	return Value{}, LanErrs.IncompatibleTypeError{Token: token}
+}
+
+// A duration * a number gives a duration
+func multiplyTimes(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType != Duration {
+		left, right = right, left
+	}
+	if left.ValueType != Duration || !isNum(right.ValueType) {
+		return Value{}, LanErrs.IncompatibleTypeError{Token: token}
+	}
+	d, err := scaleDuration(durationUncast(left.Value), right, token)
+	return durationValue(d), err
+}
+
+// A duration / a number gives a duration, a duration / a duration gives how many times one fits in the other
+func divideTimes(left Value, right Value, token tokenizer.Token) (Value, error) {
+	if left.ValueType != Duration || !(isNum(right.ValueType) || right.ValueType == Duration) {
+		return Value{}, LanErrs.IncompatibleTypeError{Token: token}
+	}
+	if right.Value == 0 && right.ValueType == Duration || isNum(right.ValueType) && isZero(right) {
+		return Value{}, LanErrs.DivisionByZeroError{Token: token}
+	}
+
+	l := decimal.FromInt(big.NewInt(int64(durationUncast(left.Value))))
+	if right.ValueType == Duration {
+		r := decimal.FromInt(big.NewInt(int64(durationUncast(right.Value))))
+		return decimalValue(l.Quo(r, settings.DecimalPrecision, settings.Rounding)), nil
+	}
+	if !isFinite(right) {
+		return Value{}, LanErrs.TimeRangeError{Token: token}
+	}
+	quotient := l.Quo(asDecimal(right), settings.DecimalPrecision, settings.Rounding).Round(0, decimal.HalfEven).BigInt()
+	if !quotient.IsInt64() {
+		return Value{}, LanErrs.TimeRangeError{Token: token}
+	}
+	return durationValue(time.Duration(quotient.Int64())), nil
+}
+
+func compareTimes(left Value, right Value) int {
+	if left.ValueType == Time {
+		l, r := times[left.Value], times[right.Value]
+		switch {
+		case l.Before(r):
+			return -1
+		case l.After(r):
+			return 1
+		}
+		return 0
+	}
+	l, r := durationUncast(left.Value), durationUncast(right.Value)
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
@@ -30,10 +30,44 @@ const (
 	BlockEnd
 	Input
 	Del
+	TypeAnnotation
+	Reassign
+	Modulo
+	IntDivide
+	Comma
+	Call
+	Nil
+	OpenSquare
+	CloseSquare
+	Colon
+	Index
+	Slice
+	List
+	Dot
+	Import
+	Try
+	Catch
+	Finally
+	Throw
+	Fn
+	Lambda
+	Arrow
+	Return
+	Record
+	Enum
+	Match
+	Case
+	Guard
+	Range
+	CompoundAssign
+	Const
 )
 
 func TKString(tK TokenKind) string {
 	return [...]string{"End", "Identifier", "String", "Int", "Decimal", "Add", "Subtract", "Divide", "Multiply", "Openbrack",
 		"Closebrack", "Exspo", "Bool", "Unary", "BooleanOp", "BoolConnector", "Assign", "EndOfStatement", "Print", "If", "While",
-		"BlockStart", "BlockEnd", "Input", "Del"}[tK]
+		"BlockStart", "BlockEnd", "Input", "Del", "TypeAnnotation", "Reassign", "Modulo", "IntDivide", "Comma", "Call", "Nil",
+		"OpenSquare", "CloseSquare", "Colon", "Index", "Slice", "List", "Dot", "Import", "Try", "Catch",
+		"Finally", "Throw", "Fn", "Lambda", "Arrow", "Return", "Record", "Enum", "Match",
+		"Case", "Guard", "Range", "CompoundAssign", "Const"}[tK]
 }
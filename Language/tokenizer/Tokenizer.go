@@ -3,13 +3,44 @@ package tokenizer
 import (
 	"errors"
 	"strconv"
+	"strings"
 	"unicode"
 )
 
 type tokenizer struct {
-	text       string
-	cursor     int
-	lineNumber int
+	text        []rune
+	cursor      int
+	lineNumber  int
+	squareDepth int
+}
+
+// Words which can't be used as identifiers
+var keywords = map[string]TokenKind{
+	"true":         Bool,
+	"false":        Bool,
+	"print":        Print,
+	"if":           If,
+	"while":        While,
+	"input":        Input,
+	"inputInt":     Input,
+	"inputDecimal": Input,
+	"del":          Del,
+	"and":          BoolConnector,
+	"or":           BoolConnector,
+	"not":          Unary,
+	"div":          IntDivide,
+	"nil":          Nil,
+	"import":       Import,
+	"try":          Try,
+	"catch":        Catch,
+	"finally":      Finally,
+	"throw":        Throw,
+	"fn":           Fn,
+	"return":       Return,
+	"record":       Record,
+	"enum":         Enum,
+	"match":        Match,
+	"const":        Const,
 }
 
 // Creates a new Tokenizer
@@ -20,14 +51,15 @@ func New() tokenizer {
 
 func (tokenizer *tokenizer) NewLine(text string) {
 	tokenizer.cursor = 0
-	tokenizer.text = text
+	tokenizer.text = []rune(text)
+	tokenizer.squareDepth = 0
 	tokenizer.lineNumber += 1
 }
 
 func (tokenizer *tokenizer) Get() (Token, error) {
 
 	for tokenizer.cursor < len(tokenizer.text) {
-		char := []rune(tokenizer.text)[tokenizer.cursor]
+		char := tokenizer.text[tokenizer.cursor]
 		switch char {
 
 		case '"':
@@ -35,11 +67,11 @@ func (tokenizer *tokenizer) Get() (Token, error) {
 			stringHead := tokenizer.cursor
 
 			for tokenizer.cursor < len(tokenizer.text) {
-				if []rune(tokenizer.text)[tokenizer.cursor] == '"' {
+				if tokenizer.text[tokenizer.cursor] == '"' {
 					stringTail := tokenizer.cursor
 					tokenizer.cursor += 1
 
-					return CreateToken(tokenizer.text[stringHead:stringTail], String, tokenizer.cursor, tokenizer.lineNumber), nil
+					return CreateToken(string(tokenizer.text[stringHead:stringTail]), String, tokenizer.cursor, tokenizer.lineNumber), nil
 				}
 				tokenizer.cursor += 1
 			}
@@ -55,7 +87,12 @@ func (tokenizer *tokenizer) Get() (Token, error) {
 			//tokenizer.lineNumber += 1
 			//return CreateToken("NL", EndOfStatment, idenStart, tokenizer.lineNumber-1), nil
 
-		case '+', '-', '*', '/', '(', ')', '^':
+		case '+', '-', '*', '/', '(', ')', '^', '%':
+			//An operator straight before = changes a variable with it, e.g. count += 1
+			if strings.ContainsRune("+-*/^", char) && tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '=' {
+				tokenizer.cursor += 2
+				return CreateToken(string(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor]), CompoundAssign, tokenizer.cursor-2, tokenizer.lineNumber), nil
+			}
 			opToken := createOperatorToken(char, tokenizer)
 			tokenizer.cursor += 1
 			return opToken, nil
@@ -63,168 +100,123 @@ func (tokenizer *tokenizer) Get() (Token, error) {
 		case '!':
 			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '=' {
 				tokenizer.cursor += 2
-				return CreateToken(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor], BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
+				return CreateToken(string(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor]), BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
 			}
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], Unary, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), Unary, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '<':
 			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '=' {
 				tokenizer.cursor += 2
-				return CreateToken(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor], BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
+				return CreateToken(string(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor]), BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
 			}
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '>':
 			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '=' {
 				tokenizer.cursor += 2
-				return CreateToken(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor], BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
+				return CreateToken(string(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor]), BooleanOp, tokenizer.cursor-2, tokenizer.lineNumber), nil
 			}
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '=':
+			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '>' {
+				tokenizer.cursor += 2
+				return CreateToken("=>", Arrow, tokenizer.cursor-2, tokenizer.lineNumber), nil
+			}
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BooleanOp, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '&':
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BoolConnector, tokenizer.cursor-1, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BoolConnector, tokenizer.cursor-1, tokenizer.lineNumber), nil
 
 		case '|':
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BoolConnector, tokenizer.cursor-1, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BoolConnector, tokenizer.cursor-1, tokenizer.lineNumber), nil
 
 		case ':':
 			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '=' {
 				tokenizer.cursor += 2
-				return CreateToken(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor], Assign, tokenizer.cursor-2, tokenizer.lineNumber), nil
+				return CreateToken(string(tokenizer.text[tokenizer.cursor-2:tokenizer.cursor]), Assign, tokenizer.cursor-2, tokenizer.lineNumber), nil
+			}
+			//Inside square brackets a colon splits a slice, e.g. name[1:3]
+			if tokenizer.squareDepth > 0 {
+				tokenizer.cursor += 1
+				return CreateToken(":", Colon, tokenizer.cursor-1, tokenizer.lineNumber), nil
+			}
+			return tokenizer.getTypeAnnotation()
+
+		case '.':
+			if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '.' {
+				tokenizer.cursor += 2
+				return CreateToken("..", Range, tokenizer.cursor-2, tokenizer.lineNumber), nil
 			}
+			tokenizer.cursor += 1
+			return CreateToken(".", Dot, tokenizer.cursor-1, tokenizer.lineNumber), nil
+
+		case '[':
+			tokenizer.squareDepth += 1
+			tokenizer.cursor += 1
+			return CreateToken("[", OpenSquare, tokenizer.cursor-1, tokenizer.lineNumber), nil
+
+		case ']':
+			tokenizer.squareDepth -= 1
+			tokenizer.cursor += 1
+			return CreateToken("]", CloseSquare, tokenizer.cursor-1, tokenizer.lineNumber), nil
+
+		case ',':
+			tokenizer.cursor += 1
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), Comma, tokenizer.cursor-1, tokenizer.lineNumber), nil
 
 		case '{':
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BlockStart, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BlockStart, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '}':
 			tokenizer.cursor += 1
-			return CreateToken(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor], BlockEnd, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[tokenizer.cursor-1:tokenizer.cursor]), BlockEnd, tokenizer.cursor, tokenizer.lineNumber), nil
 
 		case '1', '2', '3', '4', '5', '6', '7', '8', '9', '0':
 			identifierStart := tokenizer.cursor
 			tokenizer.cursor += 1
 
 			//Checking for a single digit float number
-			if tokenizer.cursor < len(tokenizer.text) && []rune(tokenizer.text)[tokenizer.cursor] == '.' {
+			if tokenizer.isDecimalPoint() {
 				tokenizer.cursor += 1
-				for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit([]rune(tokenizer.text)[tokenizer.cursor]) {
+				for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit(tokenizer.text[tokenizer.cursor]) {
 					tokenizer.cursor += 1
 				}
-				return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Decimal, tokenizer.cursor, tokenizer.lineNumber), nil
+				return CreateToken(string(tokenizer.text[identifierStart:tokenizer.cursor]), Decimal, identifierStart, tokenizer.lineNumber), nil
 			}
 
-			for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit([]rune(tokenizer.text)[tokenizer.cursor]) {
+			for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit(tokenizer.text[tokenizer.cursor]) {
 				tokenizer.cursor += 1
 
-				if tokenizer.cursor < len(tokenizer.text) && []rune(tokenizer.text)[tokenizer.cursor] == '.' {
+				if tokenizer.isDecimalPoint() {
 					tokenizer.cursor += 1
-					for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit([]rune(tokenizer.text)[tokenizer.cursor]) {
+					for tokenizer.cursor < len(tokenizer.text) && unicode.IsDigit(tokenizer.text[tokenizer.cursor]) {
 						tokenizer.cursor += 1
 					}
-					return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Decimal, tokenizer.cursor, tokenizer.lineNumber), nil
+					return CreateToken(string(tokenizer.text[identifierStart:tokenizer.cursor]), Decimal, identifierStart, tokenizer.lineNumber), nil
 				}
 			}
-			return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Int, tokenizer.cursor, tokenizer.lineNumber), nil
+			return CreateToken(string(tokenizer.text[identifierStart:tokenizer.cursor]), Int, identifierStart, tokenizer.lineNumber), nil
 		default:
-			if unicode.IsLetter(char) {
+			if unicode.IsLetter(char) || char == '_' {
 				identifierStart := tokenizer.cursor
-
-				if char == 't' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'r' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'u' {
-							if tokenizer.cursor+3 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+3] == 'e' {
-								tokenizer.cursor += 4
-								return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Bool, identifierStart, tokenizer.lineNumber), nil
-							}
-						}
-					}
-				}
-
-				if char == 'f' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'a' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'l' {
-							if tokenizer.cursor+3 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+3] == 's' {
-								if tokenizer.cursor+4 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+4] == 'e' {
-									tokenizer.cursor += 5
-									return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Bool, identifierStart, tokenizer.lineNumber), nil
-								}
-							}
-						}
-					}
-				}
-
-				if char == 'p' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'r' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'i' {
-							if tokenizer.cursor+3 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+3] == 'n' {
-								if tokenizer.cursor+4 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+4] == 't' {
-									tokenizer.cursor += 5
-									return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Print, identifierStart, tokenizer.lineNumber), nil
-								}
-							}
-						}
-					}
-				}
-
-				if char == 'i' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'f' {
-						tokenizer.cursor += 2
-						return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], If, identifierStart, tokenizer.lineNumber), nil
-					}
-				}
-
-				if char == 'w' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'h' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'i' {
-							if tokenizer.cursor+3 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+3] == 'l' {
-								if tokenizer.cursor+4 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+4] == 'e' {
-									tokenizer.cursor += 5
-									return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], While, identifierStart, tokenizer.lineNumber), nil
-								}
-							}
-						}
-					}
-				}
-
-				if char == 'i' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'n' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'p' {
-							if tokenizer.cursor+3 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+3] == 'u' {
-								if tokenizer.cursor+4 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+4] == 't' {
-									tokenizer.cursor += 5
-									return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Input, identifierStart, tokenizer.lineNumber), nil
-								}
-							}
-						}
-					}
-				}
-
-				if char == 'd' {
-					if tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == 'e' {
-						if tokenizer.cursor+2 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+2] == 'l' {
-							tokenizer.cursor += 3
-							return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Del, identifierStart, tokenizer.lineNumber), nil
-
-						}
-					}
-				}
-
 				tokenizer.cursor += 1
 
-				for tokenizer.cursor < len(tokenizer.text) && !unicode.IsSpace([]rune(tokenizer.text)[tokenizer.cursor]) {
+				for tokenizer.cursor < len(tokenizer.text) && isIdentifierChar(tokenizer.text[tokenizer.cursor]) {
 					tokenizer.cursor += 1
 				}
-				return CreateToken(tokenizer.text[identifierStart:tokenizer.cursor], Identifier, tokenizer.cursor, tokenizer.lineNumber), nil
+				word := string(tokenizer.text[identifierStart:tokenizer.cursor])
+				if kind, ok := keywords[word]; ok && !tokenizer.afterDot(identifierStart) {
+					return CreateToken(word, kind, identifierStart, tokenizer.lineNumber), nil
+				}
+				return CreateToken(word, Identifier, identifierStart, tokenizer.lineNumber), nil
 			}
 			err := "Invalid Charecter @ Line Int : " + strconv.Itoa(tokenizer.lineNumber) + "; Cursor Int : " + strconv.Itoa(tokenizer.cursor)
 			return Token{}, errors.New(err)
@@ -233,6 +225,39 @@ func (tokenizer *tokenizer) Get() (Token, error) {
 	return CreateToken("NL", EndOfStatment, tokenizer.cursor, tokenizer.lineNumber), nil
 }
 
+// Reads the type name after a `:` such as in `count: int := 0`
+func (tokenizer *tokenizer) getTypeAnnotation() (Token, error) {
+	tokenizer.cursor += 1
+	for tokenizer.cursor < len(tokenizer.text) && unicode.IsSpace(tokenizer.text[tokenizer.cursor]) {
+		tokenizer.cursor += 1
+	}
+
+	typeStart := tokenizer.cursor
+	for tokenizer.cursor < len(tokenizer.text) && isIdentifierChar(tokenizer.text[tokenizer.cursor]) {
+		tokenizer.cursor += 1
+	}
+	if typeStart == tokenizer.cursor {
+		err := "Expected type name after `:` @ Line Int : " + strconv.Itoa(tokenizer.lineNumber) + "; Cursor Int : " + strconv.Itoa(tokenizer.cursor)
+		return Token{}, errors.New(err)
+	}
+	return CreateToken(string(tokenizer.text[typeStart:tokenizer.cursor]), TypeAnnotation, typeStart, tokenizer.lineNumber), nil
+}
+
+// A . after the digits of a number is a decimal point unless it starts .., e.g. 1..5
+func (tokenizer *tokenizer) isDecimalPoint() bool {
+	return tokenizer.cursor < len(tokenizer.text) && tokenizer.text[tokenizer.cursor] == '.' &&
+		!(tokenizer.cursor+1 < len(tokenizer.text) && tokenizer.text[tokenizer.cursor+1] == '.')
+}
+
+// A word after a single . is the name of a member, so it can be a keyword, e.g. re.match
+func (tokenizer *tokenizer) afterDot(start int) bool {
+	return start > 0 && tokenizer.text[start-1] == '.' && !(start > 1 && tokenizer.text[start-2] == '.')
+}
+
+func isIdentifierChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_'
+}
+
 func createOperatorToken(char rune, tokenizer *tokenizer) Token {
 
 	switch char {
@@ -256,6 +281,9 @@ func createOperatorToken(char rune, tokenizer *tokenizer) Token {
 
 	case '^':
 		return CreateToken(string(char), Exspo, tokenizer.cursor, tokenizer.lineNumber)
+
+	case '%':
+		return CreateToken(string(char), Modulo, tokenizer.cursor, tokenizer.lineNumber)
 	}
 	return Token{}
 